@@ -0,0 +1,358 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// DefaultBatchSize is the default number of calls sent in one batch request.
+const DefaultBatchSize int = 50
+
+// DefaultBatchConcurrency is the default number of concurrent single calls used
+// when the endpoint does not accept batch requests.
+const DefaultBatchConcurrency int = 8
+
+// batchCall is a call queued in a Batch.
+type batchCall struct {
+	method string
+	params any
+	v      any
+}
+
+// BatchResult is the outcome of one call sent as part of a Batch.
+//
+// Fields:
+//   - Method: The JSON-RPC method of the call, e.g. "add-record".
+//   - Result: The typed response of the call, e.g. a *schema.RecordCreateRequestResponse
+//     for AddRecord, or nil if the call failed.
+//   - Err: The error of the call, or nil if it succeeded.
+type BatchResult struct {
+	Method string
+	Result any
+	Err    error
+}
+
+// Batch queues calls and sends them as JSON-RPC batch requests.
+//
+// Calls are sent in chunks of ChunkSize calls. If the endpoint rejects a batch
+// request with a JSON-RPC invalid-request or method-not-found error, the calls
+// are sent as concurrent single calls instead, and the client remembers not to
+// try batching again. Any other failure of a batch request, such as a 5xx or
+// 429 response or an undecodable body, is reported as the error of every call
+// in the chunk, and the calls are not sent again, since the endpoint may
+// already have processed them.
+//
+// A batch request passes through the interceptor chain as one call with the
+// method "batch", whose params are a []BatchRequest holding the individual
+// calls and whose response target is a *[]BatchResponse. Unlike the methods of
+// the sub-clients, batched calls are sent without existence or duplicate checks.
+//
+// Example usage:
+//
+//	batch := client.NewBatch()
+//	for _, r := range records {
+//	    batch.AddRecord(r)
+//	}
+//	results, err := batch.Send(ctx)
+type Batch struct {
+	client      *Client
+	calls       []batchCall
+	chunkSize   int
+	concurrency int
+}
+
+// NewBatch returns an empty Batch that sends its calls with the client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{
+		client:      c,
+		chunkSize:   DefaultBatchSize,
+		concurrency: DefaultBatchConcurrency,
+	}
+}
+
+// ChunkSize sets the maximum number of calls sent in one batch request.
+// Values below 1 are ignored.
+func (b *Batch) ChunkSize(size int) *Batch {
+	if size > 0 {
+		b.chunkSize = size
+	}
+	return b
+}
+
+// Concurrency sets the maximum number of concurrent single calls used when the
+// endpoint does not accept batch requests. Values below 1 are ignored.
+func (b *Batch) Concurrency(concurrency int) *Batch {
+	if concurrency > 0 {
+		b.concurrency = concurrency
+	}
+	return b
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) add(method string, params any, v any) *Batch {
+	b.calls = append(b.calls, batchCall{method: method, params: params, v: v})
+	return b
+}
+
+// AddRecord queues an "add-record" call. Its result is a *schema.RecordCreateRequestResponse.
+func (b *Batch) AddRecord(r schema.RecordCreateParams) *Batch {
+	return b.add("add-record", r, &schema.RecordCreateRequestResponse{})
+}
+
+// EditRecord queues an "edit-record" call. Its result is a *schema.RecordUpdateRequestResponse.
+func (b *Batch) EditRecord(r schema.RecordUpdateParams) *Batch {
+	return b.add("edit-record", r, &schema.RecordUpdateRequestResponse{})
+}
+
+// RemoveRecord queues a "remove-record" call. Its result is a *schema.RecordDeleteRequestResponse.
+func (b *Batch) RemoveRecord(r schema.RecordDeleteParams) *Batch {
+	return b.add("remove-record", r, &schema.RecordDeleteRequestResponse{})
+}
+
+// AddForward queues an "add-forward" call. Its result is a *schema.ForwardCreateRequestResponse.
+func (b *Batch) AddForward(forwardParams schema.ForwardParams) *Batch {
+	return b.add("add-forward", forwardParams, &schema.ForwardCreateRequestResponse{})
+}
+
+// RemoveForward queues a "remove-forward" call. Its result is a *schema.ForwardDeleteRequestResponse.
+func (b *Batch) RemoveForward(forwardParams schema.ForwardParams) *Batch {
+	return b.add("remove-forward", forwardParams, &schema.ForwardDeleteRequestResponse{})
+}
+
+// AddGlue queues an "add-glue" call. Its result is a *schema.GlueCreateRequestResponse.
+func (b *Batch) AddGlue(glueParams schema.GlueParams) *Batch {
+	return b.add("add-glue", glueParams, &schema.GlueCreateRequestResponse{})
+}
+
+// EditGlue queues an "edit-glue" call. Its result is a *schema.GlueUpdateRequestResponse.
+func (b *Batch) EditGlue(glueParams schema.GlueParams) *Batch {
+	return b.add("edit-glue", glueParams, &schema.GlueUpdateRequestResponse{})
+}
+
+// RemoveGlue queues a "remove-glue" call. Its result is a *schema.GlueDeleteRequestResponse.
+func (b *Batch) RemoveGlue(glueParams schema.GlueDeleteParams) *Batch {
+	return b.add("remove-glue", glueParams, &schema.GlueDeleteRequestResponse{})
+}
+
+// AddDNSSEC queues an "add-dnssec" call. Its result is a *schema.DNSSECCreateRequestResponse.
+func (b *Batch) AddDNSSEC(dnssecParams schema.DNSSECCreateParams) *Batch {
+	return b.add("add-dnssec", dnssecParams, &schema.DNSSECCreateRequestResponse{})
+}
+
+// RemoveDNSSEC queues a "remove-dnssec" call. Its result is a *schema.DNSSECDeleteRequestResponse.
+func (b *Batch) RemoveDNSSEC(dnssecParams schema.DNSSECDeleteParams) *Batch {
+	return b.add("remove-dnssec", dnssecParams, &schema.DNSSECDeleteRequestResponse{})
+}
+
+// Send sends the queued calls and returns one BatchResult per call, in the
+// order the calls were queued.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//
+// Returns:
+//   - []BatchResult: The result of every queued call.
+//   - error: nil if every call succeeded, or an error joining the errors of
+//     the calls that failed.
+func (b *Batch) Send(ctx context.Context) ([]BatchResult, error) {
+	results := make([]BatchResult, len(b.calls))
+	for start := 0; start < len(b.calls); start += b.chunkSize {
+		end := min(start+b.chunkSize, len(b.calls))
+		chunk, chunkResults := b.calls[start:end], results[start:end]
+
+		if !b.client.batchUnsupported.Load() {
+			err := b.client.sendBatch(ctx, chunk, chunkResults)
+			if !errors.Is(err, errBatchUnsupported) {
+				continue
+			}
+			b.client.batchUnsupported.Store(true)
+		}
+		b.client.sendConcurrent(ctx, chunk, chunkResults, b.concurrency)
+	}
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// batchMethod is the method name under which a JSON-RPC batch request passes
+// through the interceptor chain and the logs.
+const batchMethod string = "batch"
+
+// JSON-RPC error codes with which an endpoint rejects a batch request it does
+// not support.
+const (
+	codeInvalidRequest int = -32600
+	codeMethodNotFound int = -32601
+)
+
+// errBatchUnsupported is returned by sendBatch when the endpoint rejects a
+// batch request with a JSON-RPC invalid-request or method-not-found error,
+// meaning none of its calls was processed.
+var errBatchUnsupported = errors.New("batch requests are not supported by the endpoint")
+
+// BatchRequest is an entry of a JSON-RPC batch request. A batch request passes
+// through the interceptor chain with a []BatchRequest as its params, so that
+// interceptors can inspect or rewrite the individual calls.
+//
+// Fields:
+//   - JSONRPC: The JSON-RPC version, "2.0".
+//   - ID: The index of the call in the batch request.
+//   - Method: The JSON-RPC method of the call, e.g. "add-record".
+//   - Params: The params of the call, e.g. a schema.RecordCreateParams.
+type BatchRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// BatchResponse is an entry of a JSON-RPC batch response. A batch request
+// passes through the interceptor chain with a *[]BatchResponse as its response
+// target.
+//
+// Fields:
+//   - ID: The ID of the BatchRequest the entry answers.
+//   - Result: The raw "result" field of the response.
+//   - Error: The "error" field of the response, or nil if the call succeeded.
+type BatchResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// sendBatch sends calls as a single JSON-RPC batch request through the
+// interceptor chain and stores their outcomes in results. It returns
+// errBatchUnsupported, without touching results, if the endpoint definitely
+// rejected the batch. Any other failure is stored as the error of every call,
+// since the endpoint may have processed some of them.
+func (c *Client) sendBatch(ctx context.Context, calls []batchCall, results []BatchResult) error {
+	body := make([]BatchRequest, len(calls))
+	for i, call := range calls {
+		body[i] = BatchRequest{JSONRPC: "2.0", ID: i, Method: call.method, Params: call.params}
+	}
+
+	var entries []BatchResponse
+	if _, err := c.call(ctx, batchMethod, body, &entries); err != nil {
+		if errors.Is(err, errBatchUnsupported) {
+			return err
+		}
+		for i, call := range calls {
+			results[i] = BatchResult{Method: call.method, Err: err}
+		}
+		return err
+	}
+
+	byID := make(map[int]BatchResponse, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	for i, call := range calls {
+		results[i] = BatchResult{Method: call.method}
+		entry, ok := byID[i]
+		switch {
+		case !ok:
+			results[i].Err = fmt.Errorf("missing response for %s in batch", call.method)
+		case entry.Error != nil:
+			results[i].Err = &APIError{
+				Code:       entry.Error.Code,
+				Message:    entry.Error.Message,
+				StatusCode: http.StatusOK,
+				Method:     call.method,
+			}
+		case len(entry.Result) == 0:
+			results[i].Err = fmt.Errorf("missing result field in response")
+		default:
+			if err := json.Unmarshal(entry.Result, call.v); err != nil {
+				results[i].Err = fmt.Errorf("failed to unmarshal result: %w", err)
+				continue
+			}
+			results[i].Result = call.v
+		}
+	}
+	return nil
+}
+
+// doBatchRequest sends a batch request created by NewRequest and unmarshals
+// the entries of the batch response into v. It returns an error wrapping
+// errBatchUnsupported only for a JSON-RPC invalid-request or method-not-found
+// error; 5xx and 429 responses, other JSON-RPC errors and undecodable bodies
+// are returned as they are.
+func (c *Client) doBatchRequest(r *http.Request, v any) (any, error) {
+	resp, err := c.send(r, batchMethod)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	decodeErr := json.NewDecoder(resp.Body).Decode(&raw)
+	if decodeErr == nil && bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return nil, fmt.Errorf("failed to decode batch response: %w", err)
+		}
+		return v, nil
+	}
+
+	var wrapper struct {
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if decodeErr == nil && json.Unmarshal(raw, &wrapper) == nil && wrapper.Error != nil {
+		apiErr := &APIError{
+			Code:       wrapper.Error.Code,
+			Message:    wrapper.Error.Message,
+			StatusCode: resp.StatusCode,
+			Method:     batchMethod,
+		}
+		if apiErr.Code == codeInvalidRequest || apiErr.Code == codeMethodNotFound {
+			return nil, fmt.Errorf("%w: %w", errBatchUnsupported, apiErr)
+		}
+		return nil, apiErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Method: batchMethod}
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode batch response: %w", decodeErr)
+	}
+	return nil, errors.New("failed to decode batch response: not a JSON array")
+}
+
+// sendConcurrent sends calls as single calls, at most concurrency at a time,
+// and stores their outcomes in results.
+func (c *Client) sendConcurrent(ctx context.Context, calls []batchCall, results []BatchResult, concurrency int) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, call := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result, err := c.call(ctx, call.method, call.params, call.v)
+			results[i] = BatchResult{Method: call.method, Result: result, Err: err}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,122 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestBatchFallsBackWhenUnsupported(t *testing.T) {
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com"}}})
+	c := srv.Client()
+
+	batch := c.NewBatch().
+		AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.1"}).
+		AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.2"})
+	results, err := batch.Send(context.Background())
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Result == nil || results[1].Result == nil {
+		t.Fatalf("Send() results = %+v, want two successful results", results)
+	}
+	if got := len(srv.Records("example.com")); got != 2 {
+		t.Errorf("records after Send() = %d, want 2", got)
+	}
+}
+
+func TestBatchDoesNotResendAfterFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"proxy error page", http.StatusBadGateway, "<html>502 Bad Gateway</html>"},
+		{"rate limited", http.StatusTooManyRequests, `{"error":{"code":429,"message":"slow down"}}`},
+		{"server error", http.StatusInternalServerError, ""},
+		{"undecodable body", http.StatusOK, "not json"},
+		{"other JSON-RPC error", http.StatusOK, `{"error":{"code":-32602,"message":"invalid params"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+			c := client.NewClient(client.WithEndpoint(srv.URL))
+
+			results, err := c.NewBatch().
+				AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.1"}).
+				Send(context.Background())
+			if err == nil || results[0].Err == nil {
+				t.Fatalf("Send() error = %v, result error = %v, want errors", err, results[0].Err)
+			}
+			if got := requests.Load(); got != 1 {
+				t.Errorf("requests = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestBatchSendsArrayThroughInterceptors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			t.Errorf("request body is not a batch: %v", err)
+		}
+		fmt.Fprintf(w, `[{"id":1,"error":{"code":404,"message":"not found"}},{"id":0,"result":{"id":"1","name":"www","type":"A"}}]`)
+	}))
+	defer srv.Close()
+
+	var methods, batched []string
+	interceptor := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+		methods = append(methods, method)
+		if entries, ok := params.([]client.BatchRequest); ok {
+			for _, entry := range entries {
+				batched = append(batched, entry.Method)
+			}
+		}
+		if _, ok := v.(*[]client.BatchResponse); method == "batch" && !ok {
+			t.Errorf("batch response target = %T, want *[]client.BatchResponse", v)
+		}
+		return next(ctx, method, params, v)
+	}
+	c := client.NewClient(client.WithEndpoint(srv.URL), client.WithInterceptors(interceptor))
+
+	results, err := c.NewBatch().
+		AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.1"}).
+		RemoveRecord(schema.RecordDeleteParams{Domain: "example.com", ID: "2"}).
+		Send(context.Background())
+	if err == nil {
+		t.Fatal("Send() error = nil, want the error of the second call")
+	}
+	if record, ok := results[0].Result.(*schema.RecordCreateRequestResponse); !ok || record.ID != "1" {
+		t.Errorf("results[0].Result = %#v, want the created record", results[0].Result)
+	}
+	if !client.IsNotFound(results[1].Err) {
+		t.Errorf("results[1].Err = %v, want a not found error", results[1].Err)
+	}
+	if len(methods) != 1 || methods[0] != "batch" {
+		t.Errorf("intercepted methods = %v, want [batch]", methods)
+	}
+	if want := []string{"add-record", "remove-record"}; !slices.Equal(batched, want) {
+		t.Errorf("intercepted batch entries = %v, want %v", batched, want)
+	}
+}
@@ -0,0 +1,433 @@
+// Package cassette records exchanges with the Njalla API to a file and replays
+// them later, so integration tests can run deterministically and offline.
+//
+// A Recorder wraps a real transport and captures every JSON-RPC request and
+// response. API keys are never written to the cassette: the Authorization
+// header is dropped, and every key the Recorder sees, in an Authorization
+// header or in a response such as that of "add-token", is scrubbed from all
+// recorded bodies. Each secret is replaced by its own placeholder, e.g.
+// "redacted00000000000000000000000000000001" for the first one, the same in
+// requests and responses, so a key returned by one
+// call and sent in the params of a later one still matches on replay. A Replayer serves the recorded responses by matching the
+// JSON-RPC method and params of each request. Batch requests are recorded and
+// matched under the method "batch", with the whole batch as params.
+//
+// Example:
+//
+//	// Record once against the real API.
+//	rec := cassette.NewRecorder(nil)
+//	c := client.NewClient(client.APIKey(key), rec.Option())
+//	_, err := c.Record.ListRecords(ctx, "example.com")
+//	err = rec.Save("testdata/list-records.json")
+//
+//	// Replay in CI.
+//	rep, err := cassette.LoadReplayer("testdata/list-records.json")
+//	c := client.NewClient(rep.Option())
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+// placeholder returns the text that replaces the i-th secret seen by a
+// Recorder in recorded bodies. It has the form of an API key, so a replayed
+// key can be used with client.ContextWithAPIKey.
+func placeholder(i int) string {
+	return fmt.Sprintf("redacted%032d", i+1)
+}
+
+// Interaction is a single recorded request and response.
+type Interaction struct {
+	Method     string          `json:"method"`
+	Params     json.RawMessage `json:"params,omitempty"`
+	StatusCode int             `json:"status_code"`
+	Response   json.RawMessage `json:"response"`
+}
+
+// Cassette is an ordered list of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a cassette from a file.
+//
+// Parameters:
+//   - path: The path of the cassette file.
+//
+// Returns:
+//   - A pointer to the loaded Cassette.
+//   - An error if the file cannot be read or parsed.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to a file, replacing it if it exists. The file is
+// readable by its owner only (mode 0600).
+//
+// Parameters:
+//   - path: The path of the cassette file.
+//
+// Returns:
+//   - An error if the cassette cannot be encoded or written.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0o600)
+}
+
+// batchMethod is the method under which batch requests are recorded.
+const batchMethod string = "batch"
+
+// envelope is the part of a JSON-RPC request used to match interactions.
+type envelope struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// parseEnvelope decodes the body of a JSON-RPC request. A batch request is
+// returned with the method "batch" and the whole batch as params.
+func parseEnvelope(body []byte) (envelope, error) {
+	var env envelope
+	if trimmed := bytes.TrimSpace(body); bytes.HasPrefix(trimmed, []byte("[")) {
+		if !json.Valid(trimmed) {
+			return env, fmt.Errorf("cassette: invalid batch request body")
+		}
+		return envelope{Method: batchMethod, Params: trimmed}, nil
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		return env, fmt.Errorf("cassette: invalid request body: %w", err)
+	}
+	return env, nil
+}
+
+// readBody returns the body of a request without modifying the request, as
+// http.RoundTripper requires. The body is read through GetBody if the request
+// has it, and req is returned as it is. Otherwise req.Body is consumed and a
+// clone of req carrying a copy of the body is returned, to be sent instead.
+func readBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, req, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(data))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return data, clone, nil
+}
+
+// normalize re-encodes a JSON value with sorted object keys, so that equal
+// values compare equal regardless of formatting and key order.
+func normalize(raw json.RawMessage) string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return string(raw)
+	}
+	if v == nil {
+		return "{}"
+	}
+	if m, ok := v.(map[string]any); ok && len(m) == 0 {
+		return "{}"
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// Recorder is an http.RoundTripper that records every exchange to a Cassette.
+//
+// Interactions are kept unscrubbed in memory and scrubbed when Cassette or
+// Save is called, so a key seen late, e.g. created with "add-token" and then
+// used with client.ContextWithAPIKey, is also scrubbed from earlier
+// interactions. Secrets are numbered in the order the Recorder first sees
+// them, starting with those passed to NewRecorder, so the placeholders of a
+// cassette do not change when it is recorded again.
+type Recorder struct {
+	transport http.RoundTripper
+	mu        sync.Mutex
+	cassette  Cassette
+	secrets   []string
+}
+
+// keyFields lists the request and response fields whose string values are
+// collected as secrets.
+var keyFields = []string{"key", "api_key", "apikey", "token"}
+
+// minSecretLength is the length below which values of key fields are not
+// collected, so short values do not scrub unrelated text.
+const minSecretLength int = 16
+
+// NewRecorder returns a Recorder that sends requests with the given transport.
+// If transport is nil, http.DefaultTransport is used. API keys sent in the
+// Authorization header, and those in "key" and "token" fields of requests and
+// responses, are scrubbed automatically; additional secrets can be passed to
+// be scrubbed from recorded bodies as well.
+//
+// Parameters:
+//   - transport: The transport used to send requests to the real API.
+//   - secrets: Additional strings to scrub from the recorded bodies.
+//
+// Returns:
+//   - A pointer to the new Recorder.
+func NewRecorder(transport http.RoundTripper, secrets ...string) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{transport: transport}
+	r.addSecrets(secrets...)
+	return r
+}
+
+// addSecrets adds the secrets not seen yet to the list of secrets to scrub.
+// The caller must hold r.mu, unless r is not shared yet.
+func (r *Recorder) addSecrets(secrets ...string) {
+	for _, secret := range secrets {
+		if secret != "" && !slices.Contains(r.secrets, secret) {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+}
+
+// Option returns a ClientOption that makes a client send its requests through the recorder.
+func (r *Recorder) Option() client.ClientOption {
+	return client.WithTransport(r)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, out, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	env, err := parseEnvelope(reqBody)
+	if err != nil {
+		if out.Body != nil {
+			out.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Method:     env.Method,
+		Params:     env.Params,
+		StatusCode: resp.StatusCode,
+		Response:   respBody,
+	}
+	if !json.Valid(interaction.Response) {
+		interaction.Response, _ = json.Marshal(string(interaction.Response))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if apiKey, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Njalla "); ok {
+		r.addSecrets(apiKey)
+	}
+	r.addSecrets(collectKeys(reqBody)...)
+	r.addSecrets(collectKeys(respBody)...)
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return resp, nil
+}
+
+// collectKeys returns the string values of the key fields found anywhere in a
+// JSON document.
+func collectKeys(data []byte) []string {
+	var v any
+	if json.Unmarshal(data, &v) != nil {
+		return nil
+	}
+	var keys []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for field, value := range v {
+				if text, ok := value.(string); ok && len(text) >= minSecretLength && slices.Contains(keyFields, strings.ToLower(field)) {
+					keys = append(keys, text)
+				}
+				walk(value)
+			}
+		case []any:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(v)
+	return keys
+}
+
+// Cassette returns a copy of the interactions recorded so far, with every
+// secret seen by the recorder scrubbed.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cassette := &Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	for i, interaction := range r.cassette.Interactions {
+		interaction.Params = scrub(interaction.Params, r.secrets)
+		interaction.Response = scrub(interaction.Response, r.secrets)
+		cassette.Interactions[i] = interaction
+	}
+	return cassette
+}
+
+// Save writes the interactions recorded so far to a file, with every secret
+// seen by the recorder scrubbed.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// scrub returns a copy of data with every occurrence of the given secrets
+// replaced by their placeholders, in a single pass so that placeholders are
+// never scrubbed again. Longer secrets are matched first, so a secret
+// containing another one is replaced as a whole.
+func scrub(data []byte, secrets []string) json.RawMessage {
+	order := make([]int, len(secrets))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(secrets[b]) - len(secrets[a]) })
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, i := range order {
+		pairs = append(pairs, secrets[i], placeholder(i))
+	}
+	return json.RawMessage(strings.NewReplacer(pairs...).Replace(string(data)))
+}
+
+// Replayer is an http.RoundTripper that serves responses from a Cassette.
+//
+// Each request is matched to the first interaction with the same JSON-RPC
+// method and params that has not been replayed yet. If all matching
+// interactions have been replayed, the last one is served again.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer serving the interactions of the given cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+}
+
+// LoadReplayer loads a cassette from a file and returns a Replayer serving it.
+func LoadReplayer(path string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(cassette), nil
+}
+
+// Option returns a ClientOption that makes a client send its requests to the replayer.
+func (r *Replayer) Option() client.ClientOption {
+	return client.WithTransport(r)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _, err := readBody(req)
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	env, err := parseEnvelope(body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction, ok := r.match(env)
+	if !ok {
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s with params %s", env.Method, normalize(env.Params))
+	}
+
+	respBody := []byte(interaction.Response)
+	var text string
+	if json.Unmarshal(interaction.Response, &text) == nil {
+		respBody = []byte(text)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the interaction to replay for a request.
+func (r *Replayer) match(env envelope) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	params := normalize(env.Params)
+	last := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Method != env.Method || normalize(interaction.Params) != params {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+	if last < 0 {
+		return Interaction{}, false
+	}
+	return r.cassette.Interactions[last], true
+}
@@ -0,0 +1,278 @@
+package cassette_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/cassette"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+var (
+	testKey  = strings.Repeat("a", 40)
+	otherKey = strings.Repeat("b", 40)
+	newKey   = strings.Repeat("c", 40)
+)
+
+func newFake(t *testing.T) *njallatest.Server {
+	t.Helper()
+	srv := njallatest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Seed(njallatest.Fixture{
+		Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+		Records: map[string][]schema.RecordResponse{"example.com": {{ID: "1", Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600}}},
+	})
+	return srv
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	srv := newFake(t)
+	rec := cassette.NewRecorder(nil)
+	c := srv.Client(client.APIKey(testKey), rec.Option())
+	want, err := c.Record.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+	if _, err := c.Record.ListRecords(client.ContextWithAPIKey(ctx, otherKey), "example.com"); err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("cassette mode = %v, want 0600", perm)
+	}
+	data, _ := os.ReadFile(path)
+	for _, key := range []string{testKey, otherKey} {
+		if strings.Contains(string(data), key) {
+			t.Errorf("cassette contains API key %s", key)
+		}
+	}
+
+	rep, err := cassette.LoadReplayer(path)
+	if err != nil {
+		t.Fatalf("LoadReplayer() error = %v", err)
+	}
+	got, err := client.NewClient(client.APIKey(testKey), rep.Option()).Record.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("replayed ListRecords() error = %v", err)
+	}
+	if len(got) != len(want) || got[0].ID != want[0].ID {
+		t.Errorf("replayed ListRecords() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecorderScrubsKeysFromResponses(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"result":{"key":%q,"comment":"ci"}}`, otherKey)
+	}))
+	defer api.Close()
+
+	rec := cassette.NewRecorder(nil)
+	c := client.NewClient(client.APIKey(testKey), client.WithEndpoint(api.URL), rec.Option())
+	if _, err := c.Token.CreateToken(context.Background(), schema.TokenCreateParams{Comment: "ci"}); err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	for _, interaction := range rec.Cassette().Interactions {
+		if strings.Contains(string(interaction.Response), otherKey) {
+			t.Errorf("recorded response %s contains the created key", interaction.Response)
+		}
+	}
+}
+
+func TestReplayBatch(t *testing.T) {
+	ctx := context.Background()
+	newBatch := func(c *client.Client) *client.Batch {
+		return c.NewBatch().
+			AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "api", Content: "192.0.2.2"}).
+			RemoveRecord(schema.RecordDeleteParams{Domain: "example.com", ID: "1"})
+	}
+
+	srv := newFake(t)
+	rec := cassette.NewRecorder(nil)
+	if _, err := newBatch(srv.Client(rec.Option())).Send(ctx); err != nil {
+		t.Fatalf("recorded Send() error = %v", err)
+	}
+	interactions := rec.Cassette().Interactions
+	if len(interactions) == 0 || interactions[0].Method != "batch" {
+		t.Fatalf("first interaction = %+v, want a batch", interactions)
+	}
+
+	rep := cassette.NewReplayer(rec.Cassette())
+	results, err := newBatch(client.NewClient(rep.Option())).Send(ctx)
+	if err != nil {
+		t.Fatalf("replayed Send() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Result == nil {
+		t.Errorf("replayed Send() results = %+v", results)
+	}
+}
+
+// placeholder returns the placeholder of the n-th secret seen by a Recorder.
+func placeholder(n int) string {
+	return fmt.Sprintf("redacted%032d", n)
+}
+
+// tokenAPI serves the token methods used by RotateToken. The account has the
+// token otherKey, listed first, and the token testKey; "add-token" creates
+// newKey.
+func tokenAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		switch req.Method {
+		case "list-tokens":
+			fmt.Fprintf(w, `{"result":{"tokens":[{"key":%q,"comment":"other"},{"key":%q,"comment":"ci"}]}}`, otherKey, testKey)
+		case "add-token":
+			fmt.Fprintf(w, `{"result":{"key":%q,"comment":"ci"}}`, newKey)
+		case "list-domains":
+			if got := r.Header.Get("Authorization"); got != "Njalla "+newKey {
+				t.Errorf("verification sent Authorization %q, want the new key", got)
+			}
+			fmt.Fprint(w, `{"result":{"domains":[]}}`)
+		case "remove-token":
+			if string(req.Params) != fmt.Sprintf(`{"key":%q}`, testKey) {
+				t.Errorf("remove-token params = %s, want the old key", req.Params)
+			}
+			fmt.Fprint(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+	}))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func TestRecordAndReplayTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	api := tokenAPI(t)
+	rec := cassette.NewRecorder(nil)
+	c := client.NewClient(client.APIKey(testKey), client.WithEndpoint(api.URL), rec.Option())
+	token, err := c.Token.RotateToken(ctx, testKey, nil)
+	if err != nil {
+		t.Fatalf("recorded RotateToken() error = %v", err)
+	}
+	if token.Key != newKey {
+		t.Fatalf("recorded RotateToken() key = %q, want %q", token.Key, newKey)
+	}
+
+	recorded := rec.Cassette()
+	data, err := json.Marshal(recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{testKey, otherKey, newKey} {
+		if bytes.Contains(data, []byte(key)) {
+			t.Errorf("cassette contains key %s", key)
+		}
+	}
+
+	// Every key has its own placeholder, the same in requests and responses:
+	// testKey is seen first, in the Authorization header.
+	var removeParams string
+	for _, interaction := range recorded.Interactions {
+		if interaction.Method == "remove-token" {
+			removeParams = string(interaction.Params)
+		}
+	}
+	if want := `{"key":"` + placeholder(1) + `"}`; removeParams != want {
+		t.Errorf("recorded remove-token params = %s, want %s", removeParams, want)
+	}
+	for _, want := range []string{`"key":"` + placeholder(2) + `","comment":"other"`, `"key":"` + placeholder(1) + `","comment":"ci"`, `{"key":"` + placeholder(3) + `"`} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("cassette lacks %s:\n%s", want, data)
+		}
+	}
+
+	// Replaying with the placeholder of the old key finds the same token and
+	// sends the same params as the recorded rotation.
+	rep := cassette.NewReplayer(recorded)
+	replayed, err := client.NewClient(client.APIKey(testKey), rep.Option()).Token.RotateToken(ctx, placeholder(1), nil)
+	if err != nil {
+		t.Fatalf("replayed RotateToken() error = %v", err)
+	}
+	if replayed.Key != placeholder(3) || replayed.Comment != "ci" {
+		t.Errorf("replayed RotateToken() = %+v, want the placeholder of the new key", replayed)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRecorderDoesNotModifyRequest(t *testing.T) {
+	const body = `{"method":"list-domains","params":{}}`
+	tests := []struct {
+		name       string
+		getBody    bool
+		wantCloned bool
+	}{
+		{"with GetBody", true, false},
+		{"without GetBody", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "http://api.invalid", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.getBody {
+				req.GetBody = nil
+			}
+			originalBody := req.Body
+
+			var sent *http.Request
+			var sentBody []byte
+			rec := cassette.NewRecorder(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				sent = r
+				sentBody, _ = io.ReadAll(r.Body)
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"result":{"domains":[]}}`))}, nil
+			}))
+			resp, err := rec.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if req.Body != originalBody {
+				t.Error("RoundTrip() replaced the body of the request")
+			}
+			if cloned := sent != req; cloned != tt.wantCloned {
+				t.Errorf("transport got a clone = %t, want %t", cloned, tt.wantCloned)
+			}
+			if string(sentBody) != body {
+				t.Errorf("transport got body %q, want %q", sentBody, body)
+			}
+			if got := rec.Cassette().Interactions; len(got) != 1 || got[0].Method != "list-domains" {
+				t.Errorf("recorded interactions = %+v", got)
+			}
+		})
+	}
+}
@@ -1,5 +1,6 @@
 // Package client provides a client implementation for interacting with the Njalla DNS API.
-// It includes functionality for managing domains, DNS records, forwards, glue records, and DNSSEC.
+// It includes functionality for managing domains, DNS records, forwards, glue records, DNSSEC,
+// the account wallet, servers, VPNs and API tokens.
 //
 // The Client struct serves as the main entry point for making API requests. It supports
 // customization through various options, such as setting an API key, application name, and version.
@@ -9,7 +10,8 @@
 //
 // Usage:
 //   - Use NewClient to create a new client instance with optional configurations.
-//   - Use the sub-clients (Domain, Record, Forward, Glue, DNSSEC) for specific API operations.
+//   - Use the sub-clients (Domain, Record, Forward, Glue, DNSSEC, Wallet, Server, VPN,
+//     Token) for specific API operations.
 //
 // Example:
 //
@@ -26,18 +28,30 @@
 // Functions:
 //   - APIKey: Sets the API key for the client.
 //   - Application: Sets the application name and version for the client.
+//   - WithRetryPolicy: Sets the retry policy for failed requests.
+//   - WithHTTPClient, WithTransport: Customize how HTTP requests are sent.
+//   - WithEndpoint: Overrides the API endpoint.
+//   - WithTimeout: Sets the time limit for each request (default: DefaultTimeout).
+//   - WithSOCKS5Proxy, WithTor: Route requests through a SOCKS5 proxy such as Tor.
+//   - WithInterceptors: Wraps every RPC call with middleware such as logging or metrics.
+//   - WithLogger, WithLogLevels: Log every call with log/slog. Secrets are always redacted.
+//   - WithRecordValidation: Enables or disables client-side record validation (default: enabled).
+//   - WithTaskPolling: Sets how often asynchronous tasks such as domain registrations are checked.
 //   - NewClient: Creates a new client instance with optional configurations.
 //   - (Client) NewRequest: Creates a new HTTP request for the API.
 //   - (Client) DoRequest: Executes an HTTP request and processes the response.
 //   - (Client) UserAgent: Configures the user-agent header for the client.
 //
 // Errors:
-//   - Returns an error if the API key is invalid or if the server responds with an error status code.
+//   - Returns an error if the API key is invalid.
+//   - Returns an *APIError if the server responds with a JSON-RPC error or an error status code.
+//     Use IsNotFound, IsPermissionDenied and IsRateLimited to inspect it.
 //
 // Notes:
 //   - The API key must be a 40-character alphanumeric string.
 //   - The client automatically sets the "Authorization" header if a valid API key is provided.
 //   - The "User-Agent" header is customizable based on the application name and version.
+//   - The client never writes to stdout or stderr on its own; use WithLogger to enable logging.
 package client
 
 import (
@@ -46,8 +60,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sync/atomic"
+	"time"
 )
 
 const httpMethod string = "POST"
@@ -60,12 +78,29 @@ type Client struct {
 	applicationName    string
 	applicationVersion string
 	userAgent          string
+	retryPolicy        RetryPolicy
+	transport          http.RoundTripper
+	timeout            time.Duration
+	timeoutSet         bool
+	proxyURL           *url.URL
+	configErr          error
+	interceptors       []Interceptor
+	logger             *slog.Logger
+	logLevels          LogLevels
+	batchUnsupported   atomic.Bool
+	taskPolling        TaskPolling
+
+	skipRecordValidation bool
 
 	Domain  *DomainClient
 	Record  *RecordClient
 	Forward *ForwardClient
 	Glue    *GlueClient
 	DNSSEC  *DNSSECClient
+	Wallet  *WalletClient
+	Server  *ServerClient
+	VPN     *VPNClient
+	Token   *TokenClient
 }
 
 var validApiKey = regexp.MustCompile("[a-z0-9]{40}")
@@ -102,12 +137,76 @@ func Application(name, version string) ClientOption {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used to send requests to the API.
+// The provided client is copied, so later options such as WithTimeout and
+// WithTransport do not modify it.
+//
+// Parameters:
+//   - httpClient: The HTTP client to use.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified HTTP client to a Client instance.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithEndpoint sets the URL of the API endpoint, overriding the default Endpoint.
+// This is useful for pointing the client at a test server or a proxy.
+//
+// Parameters:
+//   - endpoint: The URL of the JSON-RPC endpoint.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified endpoint to a Client instance.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(client *Client) {
+		client.endpoint = endpoint
+	}
+}
+
+// WithTimeout sets the overall time limit for each HTTP request, including
+// connection time, redirects and reading the response body. A timeout of zero
+// means no timeout. If not set, DefaultTimeout is used unless a custom HTTP
+// client was provided with WithHTTPClient.
+//
+// Parameters:
+//   - timeout: The time limit for each request.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified timeout to a Client instance.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.timeout = timeout
+		client.timeoutSet = true
+	}
+}
+
+// WithTransport sets the round tripper used to send HTTP requests, for example
+// to route traffic through a proxy or to stub the API in tests.
+//
+// Parameters:
+//   - transport: The round tripper to use.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified transport to a Client instance.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(client *Client) {
+		client.transport = transport
+	}
+}
+
 // NewClient creates a new instance of the Client with the provided options.
 // It initializes the client with default values and applies any ClientOption
 // functions passed as arguments to customize the client configuration.
 //
 // The function also sets up various sub-clients for managing domains, records,
-// forwards, glue records, and DNSSEC.
+// forwards, glue records, DNSSEC, the wallet, servers, VPNs and API tokens.
 //
 // Parameters:
 //
@@ -120,7 +219,8 @@ func NewClient(options ...ClientOption) *Client {
 	client := &Client{
 		endpoint:    Endpoint,
 		apiKeyValid: true,
-		httpClient:  http.DefaultClient,
+		logLevels:   DefaultLogLevels(),
+		taskPolling: DefaultTaskPolling(),
 	}
 
 	for _, option := range options {
@@ -128,16 +228,46 @@ func NewClient(options ...ClientOption) *Client {
 	}
 
 	client.UserAgent()
+	client.buildHTTPClient()
+	client.buildLogger()
 
 	client.Domain = &DomainClient{client}
 	client.Record = &RecordClient{client}
 	client.Forward = &ForwardClient{client}
 	client.Glue = &GlueClient{client}
 	client.DNSSEC = &DNSSECClient{client}
+	client.Wallet = &WalletClient{client}
+	client.Server = &ServerClient{client}
+	client.VPN = &VPNClient{client}
+	client.Token = &TokenClient{client}
 
 	return client
 }
 
+// buildHTTPClient sets up the HTTP client from the configured HTTP client,
+// transport, timeout and proxy. A custom HTTP client is copied before it is modified.
+func (c *Client) buildHTTPClient() {
+	httpClient := &http.Client{Timeout: DefaultTimeout}
+	if c.httpClient != nil {
+		copied := *c.httpClient
+		httpClient = &copied
+	}
+	if c.timeoutSet {
+		httpClient.Timeout = c.timeout
+	}
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	if c.proxyURL != nil {
+		transport, err := c.proxyTransport(httpClient.Transport)
+		if err != nil {
+			c.configErr = err
+		}
+		httpClient.Transport = transport
+	}
+	c.httpClient = httpClient
+}
+
 // NewRequest creates a new HTTP request for the Njalla API client.
 // It accepts a context.Context and a request body of any type, which will be
 // marshaled into JSON format. The method sets appropriate headers, including
@@ -153,7 +283,10 @@ func NewClient(options ...ClientOption) *Client {
 //   - error: An error if the request could not be created or if the API key is invalid.
 //
 // Notes:
-//   - If the API key is invalid, the method returns an error.
+//   - If the API key is invalid, the method returns an error. An API key set on
+//     the context with ContextWithAPIKey takes precedence over the client's own.
+//   - If the client is misconfigured, e.g. a SOCKS5 proxy was requested with a
+//     transport that cannot use it, the method returns an error.
 //   - If the body is nil, the Content-Type and Accept headers are not set.
 func (c *Client) NewRequest(ctx context.Context, body any) (*http.Request, error) {
 	url := c.endpoint
@@ -163,10 +296,17 @@ func (c *Client) NewRequest(ctx context.Context, body any) (*http.Request, error
 		return nil, err
 	}
 	req.Header.Set("User-Agent", c.userAgent)
-	if !c.apiKeyValid {
+	if c.configErr != nil {
+		return nil, c.configErr
+	}
+	apiKey, apiKeyValid := c.apiKey, c.apiKeyValid
+	if key, ok := contextAPIKey(ctx); ok {
+		apiKey, apiKeyValid = key, validApiKey.MatchString(key)
+	}
+	if !apiKeyValid {
 		return nil, errors.New("invalid API key")
-	} else if c.apiKey != "" {
-		req.Header.Set("Authorization", "Njalla "+c.apiKey)
+	} else if apiKey != "" {
+		req.Header.Set("Authorization", "Njalla "+apiKey)
 	}
 	if body != nil {
 		req.Header.Set("Accept", "application/json")
@@ -177,8 +317,10 @@ func (c *Client) NewRequest(ctx context.Context, body any) (*http.Request, error
 }
 
 // DoRequest sends an HTTP request using the client's HTTP client and processes the response.
-// It expects the response body to be a JSON object containing a "result" field.
-// The "result" field is unmarshaled into the provided `v` parameter if it is not nil.
+// Failed attempts are retried according to the client's RetryPolicy.
+// It expects the response body to be a JSON object containing either a "result" field or
+// an "error" field. The "result" field is unmarshaled into the provided `v` parameter if it
+// is not nil.
 //
 // Parameters:
 //   - r: The HTTP request to be sent.
@@ -186,8 +328,9 @@ func (c *Client) NewRequest(ctx context.Context, body any) (*http.Request, error
 //
 // Returns:
 //   - any: The unmarshaled value of the "result" field if `v` is provided, or nil otherwise.
-//   - error: An error if the request fails, the response status code is not 200,
-//     the response body cannot be decoded, or the "result" field is missing.
+//   - error: An error if the request fails, the response body cannot be decoded, or the
+//     "result" field is missing. If the server responds with an "error" object or a status
+//     code other than 200, the error is an *APIError.
 //
 // Example usage:
 //
@@ -197,23 +340,40 @@ func (c *Client) NewRequest(ctx context.Context, body any) (*http.Request, error
 //	    log.Fatalf("Request failed: %v", err)
 //	}
 func (c *Client) DoRequest(r *http.Request, v any) (any, error) {
-	resp, err := c.httpClient.Do(r)
+	method := requestMethod(r)
+	resp, err := c.send(r, method)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("Server responded with status code %d", resp.StatusCode)
-	}
 
 	wrapper := struct {
 		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
 	}{}
 
 	if err := json.NewDecoder(resp.Body).Decode(&wrapper); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, &APIError{StatusCode: resp.StatusCode, Method: method}
+		}
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	if wrapper.Error != nil {
+		return nil, &APIError{
+			Code:       wrapper.Error.Code,
+			Message:    wrapper.Error.Message,
+			StatusCode: resp.StatusCode,
+			Method:     method,
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Method: method}
+	}
+
 	// If no result field was found
 	if len(wrapper.Result) == 0 {
 		return nil, fmt.Errorf("missing result field in response")
@@ -225,7 +385,27 @@ func (c *Client) DoRequest(r *http.Request, v any) (any, error) {
 		}
 		return v, nil
 	}
-	return nil, err
+	return nil, nil
+}
+
+// requestMethod returns the JSON-RPC method name of a request created by
+// NewRequest, or an empty string if it cannot be determined.
+func requestMethod(r *http.Request) string {
+	if r.GetBody == nil {
+		return ""
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	envelope := struct {
+		Method string `json:"method"`
+	}{}
+	if err := json.NewDecoder(body).Decode(&envelope); err != nil {
+		return ""
+	}
+	return envelope.Method
 }
 
 // UserAgent constructs and sets the user agent string for the Client instance.
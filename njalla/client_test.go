@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+// roundTripFunc is an http.RoundTripper backed by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestHTTPClientOptions(t *testing.T) {
+	custom := &http.Client{Timeout: 5 * time.Second}
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("unused") })
+
+	tests := []struct {
+		name          string
+		options       []client.ClientOption
+		wantTimeout   time.Duration
+		wantTransport bool
+	}{
+		{"defaults", nil, client.DefaultTimeout, false},
+		{"timeout", []client.ClientOption{client.WithTimeout(time.Second)}, time.Second, false},
+		{"no timeout", []client.ClientOption{client.WithTimeout(0)}, 0, false},
+		{"custom client keeps its timeout", []client.ClientOption{client.WithHTTPClient(custom)}, 5 * time.Second, false},
+		{"timeout overrides custom client", []client.ClientOption{client.WithHTTPClient(custom), client.WithTimeout(time.Second)}, time.Second, false},
+		{"transport on custom client", []client.ClientOption{client.WithHTTPClient(custom), client.WithTransport(transport)}, 5 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := client.HTTPClient(client.NewClient(tt.options...))
+			if httpClient.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %v, want %v", httpClient.Timeout, tt.wantTimeout)
+			}
+			if got := httpClient.Transport != nil; got != tt.wantTransport {
+				t.Errorf("Transport set = %t, want %t", got, tt.wantTransport)
+			}
+			if httpClient == custom {
+				t.Error("NewClient() uses the custom client instead of a copy")
+			}
+		})
+	}
+	if custom.Timeout != 5*time.Second || custom.Transport != nil {
+		t.Errorf("custom client was modified: %+v", custom)
+	}
+}
+
+func TestWithEndpoint(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		io.WriteString(w, `{"result":{"domains":[]}}`)
+	}))
+	defer srv.Close()
+	c := client.NewClient(client.WithEndpoint(srv.URL + "/api/1/"))
+
+	if _, err := c.Domain.ListDomains(context.Background()); err != nil {
+		t.Fatalf("ListDomains() error = %v", err)
+	}
+	if path != "/api/1/" {
+		t.Errorf("request path = %q, want /api/1/", path)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var requests []*http.Request
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"result":{"domains":[{"name":"example.com"}]}}`)),
+			Request:    r,
+		}, nil
+	})
+	c := client.NewClient(client.WithTransport(transport))
+
+	domains, err := c.Domain.ListDomains(context.Background())
+	if err != nil {
+		t.Fatalf("ListDomains() error = %v", err)
+	}
+	if len(domains) != 1 || domains[0].Name != "example.com" {
+		t.Errorf("ListDomains() = %+v, want example.com", domains)
+	}
+	if len(requests) != 1 || requests[0].URL.String() != client.Endpoint {
+		t.Errorf("requests = %v, want one request to %s", requests, client.Endpoint)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+	c := client.NewClient(
+		client.WithEndpoint(srv.URL),
+		client.WithTimeout(20*time.Millisecond),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}),
+	)
+
+	start := time.Now()
+	_, err := c.Domain.ListDomains(context.Background())
+	if err == nil {
+		t.Fatal("ListDomains() error = nil, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ListDomains() returned after %v, want the timeout to stop it", elapsed)
+	}
+}
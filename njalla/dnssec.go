@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+type DNSSECClient struct {
+	client *Client
+}
+
+// sameDNSSEC reports whether an existing DNSSEC record matches the given params.
+func sameDNSSEC(record schema.DNSSECResponse, dnssecParams schema.DNSSECCreateParams) bool {
+	return record.Algorithm == dnssecParams.Algorithm &&
+		record.Digest == dnssecParams.Digest &&
+		record.DigestType == dnssecParams.DigestType &&
+		record.KeyTag == dnssecParams.KeyTag &&
+		record.PublicKey == dnssecParams.PublicKey
+}
+
+// ListDNSSEC retrieves the DNSSEC (DS) records configured for the specified domain.
+// It sends a request to the Njalla API using the "list-dnssec" method and
+// returns the records as a slice of schema.DNSSECResponse.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name for which to retrieve DNSSEC records.
+//
+// Returns:
+//   - []schema.DNSSECResponse: A slice containing the DNSSEC records for the domain.
+//   - error: An error if the request fails or the response cannot be processed.
+func (c *DNSSECClient) ListDNSSEC(ctx context.Context, domain string) ([]schema.DNSSECResponse, error) {
+	const method string = "list-dnssec"
+	var responseScheme schema.DNSSECListRequestResponse
+
+	params := schema.DNSSECListParams{
+		Domain: domain,
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.DNSSECListRequestResponse)
+	return response.DNSSec, nil
+}
+
+// AddDNSSEC adds a new DNSSEC (DS) record to the specified domain.
+// It first checks if a record with the same algorithm, digest, digest type,
+// key tag and public key already exists for the domain, and returns an error
+// if a duplicate is found.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - dnssecParams: The parameters for the DNSSEC record, including the domain,
+//     algorithm, digest, digest type, key tag and public key.
+//
+// Returns:
+//   - A pointer to a DNSSECCreateRequestResponse containing the details of the created record.
+//   - An error if the record already exists or if the request fails.
+func (c *DNSSECClient) AddDNSSEC(ctx context.Context, dnssecParams schema.DNSSECCreateParams) (*schema.DNSSECCreateRequestResponse, error) {
+	const method string = "add-dnssec"
+	var responseScheme schema.DNSSECCreateRequestResponse
+
+	existingRecords, err := c.ListDNSSEC(ctx, dnssecParams.Domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range existingRecords {
+		if sameDNSSEC(record, dnssecParams) {
+			return nil, &ResourceError{Err: ErrDNSSECExists, Domain: dnssecParams.Domain, ID: strconv.Itoa(dnssecParams.KeyTag)}
+		}
+	}
+
+	resp, err := c.client.call(ctx, method, dnssecParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.DNSSECCreateRequestResponse)
+	return response, nil
+}
+
+// RemoveDNSSEC removes a DNSSEC (DS) record from the specified domain.
+// It first checks if a record with the given ID exists for the domain.
+// If the record does not exist, it returns an error.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - dnssecParams: The parameters identifying the record to remove, including the domain and record ID.
+//
+// Returns:
+//   - A pointer to a DNSSECDeleteRequestResponse containing the response from the server.
+//   - An error if the record does not exist or if the request fails.
+func (c *DNSSECClient) RemoveDNSSEC(ctx context.Context, dnssecParams schema.DNSSECDeleteParams) (*schema.DNSSECDeleteRequestResponse, error) {
+	const method string = "remove-dnssec"
+	var responseScheme schema.DNSSECDeleteRequestResponse
+	var exists bool
+
+	// Check if the record exists
+	existingRecords, err := c.ListDNSSEC(ctx, dnssecParams.Domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range existingRecords {
+		if record.ID == dnssecParams.ID {
+			exists = true
+			break
+		}
+	}
+
+	if !exists {
+		return nil, &ResourceError{Err: ErrDNSSECNotFound, Domain: dnssecParams.Domain, ID: dnssecParams.ID}
+	}
+
+	resp, err := c.client.call(ctx, method, dnssecParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.DNSSECDeleteRequestResponse)
+	return response, nil
+}
@@ -0,0 +1,113 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// dnssecRecord is the DS record every DNSSEC test starts from. Seed assigns
+// it the ID "1".
+var dnssecRecord = schema.DNSSECResponse{Algorithm: 13, Digest: "ABCDEF0123456789", DigestType: 2, KeyTag: 12345}
+
+func newDNSSECServer(t *testing.T) *njallatest.Server {
+	t.Helper()
+	srv := njallatest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Seed(njallatest.Fixture{
+		Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+		DNSSEC:  map[string][]schema.DNSSECResponse{"example.com": {dnssecRecord}},
+	})
+	return srv
+}
+
+func TestListDNSSEC(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":{"dnssec":[{"id":"ds1","algorithm":13,"digest":"ABCDEF0123456789","digest_type":2,"key_tag":12345,"public_key":"mdsswUyr3DPW132mOi8V9xESWE8jTo0d"}]}}`)
+	}))
+	defer srv.Close()
+	c := client.NewClient(client.WithEndpoint(srv.URL))
+
+	got, err := c.DNSSEC.ListDNSSEC(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ListDNSSEC() error = %v", err)
+	}
+	want := []schema.DNSSECResponse{{ID: "ds1", Algorithm: 13, Digest: "ABCDEF0123456789", DigestType: 2, KeyTag: 12345, PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0d"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListDNSSEC() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAddDNSSEC(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      schema.DNSSECCreateParams
+		wantErr     error
+		wantRecords int
+	}{
+		{"new key tag", schema.DNSSECCreateParams{Algorithm: 13, Digest: "0123456789ABCDEF", DigestType: 2, KeyTag: 54321}, nil, 2},
+		{"duplicate", schema.DNSSECCreateParams{Algorithm: 13, Digest: "ABCDEF0123456789", DigestType: 2, KeyTag: 12345}, client.ErrDNSSECExists, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newDNSSECServer(t)
+			c := srv.Client()
+			tt.params.Domain = "example.com"
+
+			_, err := c.DNSSEC.AddDNSSEC(context.Background(), tt.params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddDNSSEC() error = %v, want %v", err, tt.wantErr)
+			}
+			var resourceErr *client.ResourceError
+			if tt.wantErr != nil && (!errors.As(err, &resourceErr) || resourceErr.Domain != "example.com" || resourceErr.ID != "12345") {
+				t.Errorf("AddDNSSEC() error = %#v, want a ResourceError for key tag 12345 of example.com", err)
+			}
+			if got := len(srv.DNSSEC("example.com")); got != tt.wantRecords {
+				t.Errorf("DNSSEC records = %d, want %d", got, tt.wantRecords)
+			}
+			if tt.wantErr != nil && len(srv.RequestsFor("add-dnssec")) != 0 {
+				t.Error("add-dnssec was sent for a duplicate record")
+			}
+		})
+	}
+}
+
+func TestRemoveDNSSEC(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		wantErr     error
+		wantRecords int
+	}{
+		{"existing", "1", nil, 0},
+		{"missing", "42", client.ErrDNSSECNotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newDNSSECServer(t)
+			c := srv.Client()
+
+			_, err := c.DNSSEC.RemoveDNSSEC(context.Background(), schema.DNSSECDeleteParams{Domain: "example.com", ID: tt.id})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RemoveDNSSEC() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && !client.IsNotFound(err) {
+				t.Errorf("IsNotFound(%v) = false", err)
+			}
+			if got := len(srv.DNSSEC("example.com")); got != tt.wantRecords {
+				t.Errorf("DNSSEC records = %d, want %d", got, tt.wantRecords)
+			}
+			if tt.wantErr != nil && len(srv.RequestsFor("remove-dnssec")) != 0 {
+				t.Error("remove-dnssec was sent for a missing record")
+			}
+		})
+	}
+}
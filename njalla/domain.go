@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ajquack/njalla-dns-go/njalla/schema"
 )
@@ -25,15 +24,7 @@ func (c *DomainClient) GetDomain(ctx context.Context, domainParams schema.GetDom
 	const method string = "get-domain"
 	var responseScheme schema.GetDomainRequestResponse
 
-	body := schema.GetDomainRequest{
-		Method: method,
-		Params: domainParams,
-	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, domainParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -54,14 +45,7 @@ func (c *DomainClient) ListDomains(ctx context.Context) ([]schema.ListDomainResp
 	const method string = "list-domains"
 	var responseScheme schema.ListDomainsRequestResponse
 
-	body := schema.ListDomainsRequest{
-		Method: method,
-	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, schema.ListDomainParams{}, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +56,10 @@ func (c *DomainClient) ListDomains(ctx context.Context) ([]schema.ListDomainResp
 // EditDomain updates the settings of an existing domain.
 //
 // This method allows you to modify the mail forwarding, DNSSEC, and lock
-// settings of a specified domain. It first checks if the domain exists
-// by retrieving the list of existing domains. If the domain does not exist,
-// an error is returned.
+// settings of a specified domain. All three settings are always sent; use
+// PatchDomain to change some of them while keeping the others. It first
+// checks if the domain exists by retrieving the list of existing domains.
+// If the domain does not exist, an error is returned.
 //
 // Parameters:
 //   - ctx: The context for the request, used for cancellation and deadlines.
@@ -105,27 +90,118 @@ func (c *DomainClient) EditDomain(ctx context.Context, domain string, mailForwar
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("domain %s does not exists", domain)
+		return nil, &ResourceError{Err: ErrDomainNotFound, Domain: domain}
 	}
 
-	body := schema.UpdateDomainRequest{
-		Method: method,
-		Params: schema.UpdateDomainParams{
-			Domain:         domain,
-			MailForwarding: mailForwarding,
-			DNSSEC:         dnssec,
-			Lock:           lock,
-		},
+	params := schema.UpdateDomainParams{
+		Domain:         domain,
+		MailForwarding: mailForwarding,
+		DNSSEC:         dnssec,
+		Lock:           lock,
 	}
-	req, err := c.client.NewRequest(ctx, body)
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println(req.Body)
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	response := resp.(*schema.UpdateDomainRequestResponse)
+	return response, nil
+}
+
+// FindDomains searches for domains matching a query and reports whether they
+// can be registered and at what price.
+// It sends a request to the "find-domains" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - query: The name to search for, e.g. "example" or "example.com".
+//
+// Returns:
+//   - A slice of schema.FindDomainResponse containing the name, availability
+//     status and yearly price in euros of each matching domain.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *DomainClient) FindDomains(ctx context.Context, query string) ([]schema.FindDomainResponse, error) {
+	const method string = "find-domains"
+	var responseScheme schema.FindDomainRequestResponse
+
+	params := schema.FindDomainParams{Query: query}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
-	response := resp.(*schema.UpdateDomainRequestResponse)
-	return response, nil
+	response := resp.(*schema.FindDomainRequestResponse)
+	return response.Domains, nil
+}
+
+// RegisterDomain registers a domain and waits until the registration completes.
+// It sends a request to the "register-domain" API endpoint, which charges the
+// account wallet, and follows the resulting task with WaitForTask.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//     If it is done while waiting, the registration may still complete.
+//   - domain: The name of the domain to register.
+//   - years: The number of years to register the domain for. Values below 1 default to 1.
+//
+// Returns:
+//   - A pointer to schema.GetDomainRequestResponse containing the registered domain.
+//   - An error if the request fails, the registration task fails, or the context is done.
+func (c *DomainClient) RegisterDomain(ctx context.Context, domain string, years int) (*schema.GetDomainRequestResponse, error) {
+	const method string = "register-domain"
+
+	params := schema.RegisterDomainParams{Domain: domain, Years: max(years, 1)}
+	return c.runDomainTask(ctx, method, params, domain)
+}
+
+// RenewDomain renews a domain and waits until the renewal completes.
+// It sends a request to the "renew-domain" API endpoint, which charges the
+// account wallet, and follows the resulting task with WaitForTask.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//     If it is done while waiting, the renewal may still complete.
+//   - domain: The name of the domain to renew.
+//   - years: The number of years to renew the domain for. Values below 1 default to 1.
+//
+// Returns:
+//   - A pointer to schema.GetDomainRequestResponse containing the renewed domain.
+//   - An error if the request fails, the renewal task fails, or the context is done.
+func (c *DomainClient) RenewDomain(ctx context.Context, domain string, years int) (*schema.GetDomainRequestResponse, error) {
+	const method string = "renew-domain"
+
+	params := schema.RenewDomainParams{Domain: domain, Years: max(years, 1)}
+	return c.runDomainTask(ctx, method, params, domain)
+}
+
+// WaitForDomainTask waits for an asynchronous task affecting a domain, such as
+// a registration started elsewhere, and returns the domain once it completes.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//   - taskID: The ID of the task.
+//   - domain: The name of the domain the task affects.
+//
+// Returns:
+//   - A pointer to schema.GetDomainRequestResponse containing the final state of the domain.
+//   - A *TaskError if the task fails, or an error if a request fails or the context is done.
+func (c *DomainClient) WaitForDomainTask(ctx context.Context, taskID string, domain string) (*schema.GetDomainRequestResponse, error) {
+	if _, err := c.client.WaitForTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return c.GetDomain(ctx, schema.GetDomainParams{Domain: domain})
+}
+
+// runDomainTask sends a method that starts an asynchronous task for a domain
+// and waits for the task.
+func (c *DomainClient) runDomainTask(ctx context.Context, method string, params any, domain string) (*schema.GetDomainRequestResponse, error) {
+	var responseScheme schema.TaskRequestResponse
+
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.TaskRequestResponse)
+	if response.Task == "" {
+		return c.GetDomain(ctx, schema.GetDomainParams{Domain: domain})
+	}
+	return c.WaitForDomainTask(ctx, response.Task, domain)
 }
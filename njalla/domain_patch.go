@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// PatchDomain changes only the settings of a domain that are set in the patch.
+// Unlike EditDomain, which always sends mail forwarding, DNSSEC and lock, it
+// fetches the current state with GetDomain, applies the fields set in the
+// patch, and sends the merged settings, so settings the caller did not set
+// keep their current value. DNSSEC is only sent when the patch sets it.
+//
+// If the patch sets no field, no change is sent and the current state is
+// returned as both the before and after state.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//   - p: The patch, identifying the domain by name. Set fields with Ptr.
+//
+// Returns:
+//   - A pointer to schema.GetDomainRequestResponse with the state before the change.
+//   - A pointer to schema.UpdateDomainRequestResponse with the state after the change.
+//   - An error if a request fails.
+//
+// Example usage:
+//
+//	before, after, err := c.Domain.PatchDomain(ctx, schema.DomainPatchParams{
+//	    Domain: "example.com",
+//	    Lock:   client.Ptr(true),
+//	})
+func (c *DomainClient) PatchDomain(ctx context.Context, p schema.DomainPatchParams) (*schema.GetDomainRequestResponse, *schema.UpdateDomainRequestResponse, error) {
+	const method string = "edit-domain"
+	var responseScheme schema.UpdateDomainRequestResponse
+
+	before, err := c.GetDomain(ctx, schema.GetDomainParams{Domain: p.Domain})
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.MailForwarding == nil && p.DNSSEC == nil && p.Lock == nil && p.Autorenew == nil {
+		return before, domainUpdateResponse(*before), nil
+	}
+
+	// Settings the patch does not set are sent with their current value. DNSSEC
+	// is the exception: the domain reports a DNSSEC type rather than a flag, and
+	// sending the flag again could change the type, so it is only sent when set.
+	params := p
+	if params.MailForwarding == nil {
+		params.MailForwarding = Ptr(before.Mailforwarding)
+	}
+	if params.Lock == nil {
+		params.Lock = Ptr(before.Locked)
+	}
+	if params.Autorenew == nil {
+		params.Autorenew = Ptr(before.Autorenew)
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return before, nil, err
+	}
+	response := resp.(*schema.UpdateDomainRequestResponse)
+	return before, response, nil
+}
+
+// domainUpdateResponse converts the state of a domain returned by "get-domain"
+// to the response of "edit-domain".
+func domainUpdateResponse(d schema.GetDomainRequestResponse) *schema.UpdateDomainRequestResponse {
+	return &schema.UpdateDomainRequestResponse{
+		Name:           d.Name,
+		Status:         d.Status,
+		Expiry:         d.Expiry,
+		Autorenew:      d.Autorenew,
+		Locked:         d.Locked,
+		Mailforwarding: d.Mailforwarding,
+		MaxNameservers: d.MaxNameservers,
+		Nameservers:    d.Nameservers,
+		DNSSECType:     d.DNSSECType,
+		MaxStaticPages: d.MaxStaticPages,
+	}
+}
@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestPatchDomain(t *testing.T) {
+	initial := schema.GetDomainRequestResponse{Name: "example.com", Status: "active", Mailforwarding: true, Locked: true, DNSSECType: "ds"}
+	tests := []struct {
+		name       string
+		dnssecType string
+		patch      schema.DomainPatchParams
+		want       schema.GetDomainRequestResponse
+		wantEdits  int
+	}{
+		{"empty patch", "", schema.DomainPatchParams{}, initial, 0},
+		{"unlock", "", schema.DomainPatchParams{Lock: client.Ptr(false)}, schema.GetDomainRequestResponse{Mailforwarding: true, DNSSECType: "ds"}, 1},
+		{"disable DNSSEC and enable autorenew", "", schema.DomainPatchParams{DNSSEC: client.Ptr(false), Autorenew: client.Ptr(true)}, schema.GetDomainRequestResponse{Mailforwarding: true, Locked: true, Autorenew: true}, 1},
+		{"unset DNSSEC keeps its type", "dnskey", schema.DomainPatchParams{MailForwarding: client.Ptr(false)}, schema.GetDomainRequestResponse{Locked: true, DNSSECType: "dnskey"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := njallatest.NewServer()
+			defer srv.Close()
+			domain := initial
+			if tt.dnssecType != "" {
+				domain.DNSSECType = tt.dnssecType
+			}
+			srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{domain}})
+			c := srv.Client()
+			tt.patch.Domain = "example.com"
+
+			before, after, err := c.Domain.PatchDomain(context.Background(), tt.patch)
+			if err != nil {
+				t.Fatalf("PatchDomain() error = %v", err)
+			}
+			if before.Locked != domain.Locked || before.DNSSECType != domain.DNSSECType {
+				t.Errorf("PatchDomain() before = %+v, want the initial state", before)
+			}
+			got, _ := srv.Domain("example.com")
+			if got.Mailforwarding != tt.want.Mailforwarding || got.Locked != tt.want.Locked ||
+				got.Autorenew != tt.want.Autorenew || got.DNSSECType != tt.want.DNSSECType {
+				t.Errorf("stored domain = %+v, want %+v", got, tt.want)
+			}
+			if after.Locked != got.Locked || after.Autorenew != got.Autorenew {
+				t.Errorf("PatchDomain() after = %+v, want the stored state %+v", after, got)
+			}
+			edits := srv.RequestsFor("edit-domain")
+			if len(edits) != tt.wantEdits {
+				t.Fatalf("edit-domain requests = %d, want %d", len(edits), tt.wantEdits)
+			}
+			for _, edit := range edits {
+				var params map[string]json.RawMessage
+				if err := json.Unmarshal(edit.Params, &params); err != nil {
+					t.Fatalf("failed to decode params: %v", err)
+				}
+				if _, sent := params["dnssec"]; sent != (tt.patch.DNSSEC != nil) {
+					t.Errorf("dnssec sent = %t, want %t", sent, tt.patch.DNSSEC != nil)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Domain availability statuses reported by "find-domains".
+const (
+	DomainAvailable  string = "available"
+	DomainTaken      string = "taken"
+	DomainInProgress string = "in progress"
+	DomainFailed     string = "failed"
+)
+
+// DefaultSearchConcurrency is the default number of concurrent "find-domains"
+// calls made by CheckDomains.
+const DefaultSearchConcurrency int = 4
+
+// domainStatusOrder ranks availability statuses for sorting, most available first.
+var domainStatusOrder = []string{DomainAvailable, DomainInProgress, DomainTaken, DomainFailed}
+
+// CheckDomains checks the availability and price of candidate names across TLDs.
+// It runs one "find-domains" search per candidate, at most concurrency at a time,
+// and keeps the results whose name is the candidate under one of the TLDs.
+//
+// A candidate that contains a dot, e.g. "example.com", is taken as a full domain
+// name and only that domain is kept. If tlds is empty, every TLD returned by the
+// API is kept.
+//
+// The results are sorted with available domains first, then by price, cheapest
+// first, then by name. A failed search does not stop the others, but once ctx
+// is done no further searches are started.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//   - candidates: The names to check, e.g. "example" or "example.com".
+//   - tlds: The TLDs to check each name under, e.g. "com" or ".net".
+//   - concurrency: The maximum number of concurrent searches. Values below 1
+//     default to DefaultSearchConcurrency.
+//
+// Returns:
+//   - A slice of schema.FindDomainResponse with the sorted results of the
+//     searches that succeeded.
+//   - An error joining the errors of the searches that failed, or nil.
+//
+// Example usage:
+//
+//	results, err := c.Domain.CheckDomains(ctx, []string{"example", "examp1e"}, []string{"com", "net"}, 0)
+//	for _, d := range results {
+//	    if d.Status == client.DomainAvailable {
+//	        fmt.Println(d.Name, d.Price)
+//	    }
+//	}
+func (c *DomainClient) CheckDomains(ctx context.Context, candidates []string, tlds []string, concurrency int) ([]schema.FindDomainResponse, error) {
+	if concurrency < 1 {
+		concurrency = DefaultSearchConcurrency
+	}
+	wanted := make(map[string]bool, len(tlds))
+	for _, tld := range tlds {
+		wanted[strings.ToLower(strings.TrimPrefix(tld, "."))] = true
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		seen    = make(map[string]bool)
+		results []schema.FindDomainResponse
+		errs    []error
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, candidate := range candidates {
+		candidate = strings.ToLower(strings.TrimSuffix(candidate, "."))
+		sem <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			// Candidates not searched yet are skipped once ctx is done.
+			<-sem
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			domains, err := c.FindDomains(ctx, candidate)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to search %s: %w", candidate, err))
+				return
+			}
+			for _, d := range domains {
+				name := strings.ToLower(d.Name)
+				if seen[name] || !matchesCandidate(name, candidate, wanted) {
+					continue
+				}
+				seen[name] = true
+				results = append(results, d)
+			}
+		}()
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b schema.FindDomainResponse) int {
+		return cmp.Or(
+			cmp.Compare(domainStatusRank(a.Status), domainStatusRank(b.Status)),
+			cmp.Compare(a.Price, b.Price),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return results, errors.Join(errs...)
+}
+
+// matchesCandidate reports whether the domain name is the candidate, or the
+// candidate under one of the wanted TLDs.
+func matchesCandidate(name, candidate string, wanted map[string]bool) bool {
+	if strings.Contains(candidate, ".") {
+		return name == candidate
+	}
+	label, tld, ok := strings.Cut(name, ".")
+	if !ok || label != candidate {
+		return false
+	}
+	return len(wanted) == 0 || wanted[tld]
+}
+
+// domainStatusRank returns the sort rank of an availability status. Unknown
+// statuses sort last.
+func domainStatusRank(status string) int {
+	if i := slices.Index(domainStatusOrder, status); i >= 0 {
+		return i
+	}
+	return len(domainStatusOrder)
+}
@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+// findDomainsAPI serves "find-domains" with the given responses, keyed by
+// query, and records the queries it receives. Queries without a response fail.
+func findDomainsAPI(t *testing.T, responses map[string]string, handle func(query string)) (*httptest.Server, func() []string) {
+	t.Helper()
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params struct {
+				Query string `json:"query"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		mu.Lock()
+		queries = append(queries, req.Params.Query)
+		mu.Unlock()
+		if handle != nil {
+			handle(req.Params.Query)
+		}
+		response, ok := responses[req.Params.Query]
+		if !ok {
+			fmt.Fprint(w, `{"error":{"code":500,"message":"search failed"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"result":{"domains":%s}}`, response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Sorted(slices.Values(queries))
+	}
+}
+
+func TestCheckDomains(t *testing.T) {
+	srv, queries := findDomainsAPI(t, map[string]string{
+		"example": `[
+			{"name":"example.com","status":"available","price":12},
+			{"name":"example.net","status":"available","price":10},
+			{"name":"example.io","status":"available","price":10},
+			{"name":"example.org","status":"available","price":1},
+			{"name":"examples.com","status":"available","price":1}
+		]`,
+		"foo.com": `[
+			{"name":"foo.com","status":"available","price":10},
+			{"name":"foo.net","status":"available","price":3}
+		]`,
+		"bar": `[
+			{"name":"bar.com","status":"taken","price":4},
+			{"name":"bar.net","status":"in progress","price":20}
+		]`,
+	}, nil)
+	c := client.NewClient(client.WithEndpoint(srv.URL))
+
+	results, err := c.Domain.CheckDomains(context.Background(), []string{"example", "Foo.com.", "broken", "bar"}, []string{"com", ".net", "IO"}, 2)
+	if err == nil || !strings.Contains(err.Error(), "failed to search broken") {
+		t.Errorf("CheckDomains() error = %v, want the error of the broken search only", err)
+	}
+
+	var got []string
+	for _, d := range results {
+		got = append(got, fmt.Sprintf("%s %s %d", d.Name, d.Status, d.Price))
+	}
+	want := []string{
+		// Available first; example.io, example.net and foo.com tie on price
+		// and are ordered by name.
+		"example.io available 10",
+		"example.net available 10",
+		"foo.com available 10",
+		"example.com available 12",
+		"bar.net in progress 20",
+		"bar.com taken 4",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("CheckDomains() = %q, want %q", got, want)
+	}
+	if got, want := queries(), []string{"bar", "broken", "example", "foo.com"}; !slices.Equal(got, want) {
+		t.Errorf("queries = %q, want %q", got, want)
+	}
+}
+
+func TestCheckDomainsStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv, queries := findDomainsAPI(t, map[string]string{
+		"one": `[{"name":"one.com","status":"available","price":10}]`,
+	}, func(query string) {
+		if query == "two" {
+			cancel()
+		}
+	})
+	c := client.NewClient(client.WithEndpoint(srv.URL))
+
+	results, err := c.Domain.CheckDomains(ctx, []string{"one", "two", "three", "four", "five"}, nil, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CheckDomains() error = %v, want context.Canceled", err)
+	}
+	if len(results) != 1 || results[0].Name != "one.com" {
+		t.Errorf("CheckDomains() = %+v, want the result of the search before the cancellation", results)
+	}
+	if got, want := queries(), []string{"one", "two"}; !slices.Equal(got, want) {
+		t.Errorf("queries = %q, want %q", got, want)
+	}
+}
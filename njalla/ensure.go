@@ -0,0 +1,385 @@
+package client
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Change reports what an Ensure method did to converge to the requested state.
+type Change string
+
+const (
+	ChangeCreated   Change = "created"
+	ChangeUpdated   Change = "updated"
+	ChangeUnchanged Change = "unchanged"
+	ChangeDeleted   Change = "deleted"
+)
+
+// singletonRecordTypes lists the record types of which a name can have only
+// one record. EnsureRecord updates such a record in place instead of adding
+// a second one.
+var singletonRecordTypes = []RecordType{RecordTypeCNAME, RecordTypeANAME, RecordTypeDynamic}
+
+// recordMutableFields lists, per record type, the data fields that do not
+// identify a record. An MX record is identified by its mail server and an SRV
+// record by its target and port, so EnsureRecord converges the priority and
+// weight of an existing record instead of adding a second one.
+var recordMutableFields = map[RecordType][]string{
+	RecordTypeMX:  {"prio"},
+	RecordTypeSRV: {"prio", "weight"},
+}
+
+// recordIdentityKey identifies a record within an RRset for EnsureRecord. It is
+// the recordDataKey of r without the mutable fields of its type.
+func recordIdentityKey(r schema.RecordCreateParams) string {
+	for _, field := range recordMutableFields[RecordType(r.Type)] {
+		switch field {
+		case "prio":
+			r.Prio = 0
+		case "weight":
+			r.Weight = 0
+		}
+	}
+	return recordDataKey(r)
+}
+
+// EnsureRecord makes sure a DNS record exists. A record with the same name,
+// type and identifying data is converged to r: it is left alone if nothing
+// differs, or updated in place if its TTL or one of its mutable fields (the
+// priority of an MX record, the priority and weight of an SRV record) differs.
+// For CNAME, ANAME and dynamic records, of which a name can have only one, an
+// existing record with different data is updated in place. Otherwise the
+// record is created.
+//
+// Every data field the record type uses is sent as given in r, zero values
+// included, so an MX record given without a priority gets priority 0.
+//
+// It uses one "list-records" call and at most one mutation, so it is safe and
+// cheap to call repeatedly.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - r: The record that should exist.
+//
+// Returns:
+//   - A pointer to the record as it exists after the call.
+//   - The Change made: ChangeCreated, ChangeUpdated or ChangeUnchanged.
+//   - An error if the record is invalid or a request fails.
+func (c *RecordClient) EnsureRecord(ctx context.Context, r schema.RecordCreateParams) (*schema.RecordResponse, Change, error) {
+	if !c.client.skipRecordValidation {
+		if err := ValidateRecord(r); err != nil {
+			return nil, "", err
+		}
+	}
+
+	existingRecords, err := c.ListRecords(ctx, r.Domain)
+	if err != nil {
+		return nil, "", err
+	}
+	members := filterRRset(existingRecords, r.Name, RecordType(r.Type))
+
+	identity, key := recordIdentityKey(r), recordDataKey(r)
+	for _, record := range members {
+		existing := recordCreateParams(r.Domain, record)
+		if recordIdentityKey(existing) != identity {
+			continue
+		}
+		if recordDataKey(existing) == key && (r.TTL == 0 || r.TTL == record.TTL) {
+			return &record, ChangeUnchanged, nil
+		}
+		return c.ensureRecordUpdate(ctx, r, record)
+	}
+
+	if slices.Contains(singletonRecordTypes, RecordType(r.Type)) && len(members) > 0 {
+		return c.ensureRecordUpdate(ctx, r, members[0])
+	}
+
+	var created schema.RecordCreateRequestResponse
+	if _, err := c.client.call(ctx, "add-record", r, &created); err != nil {
+		return nil, "", err
+	}
+	return &created, ChangeCreated, nil
+}
+
+// ensureRecordUpdate overwrites an existing record with r, sending the TTL if
+// it is set and every data field the record type uses.
+func (c *RecordClient) ensureRecordUpdate(ctx context.Context, r schema.RecordCreateParams, existing schema.RecordResponse) (*schema.RecordResponse, Change, error) {
+	params := recordTypePatch(r)
+	params.ID = existing.ID
+	if r.TTL == 0 {
+		params.TTL = nil
+	}
+	var updated schema.RecordUpdateRequestResponse
+	if _, err := c.client.call(ctx, "edit-record", params, &updated); err != nil {
+		return nil, "", err
+	}
+	return &updated, ChangeUpdated, nil
+}
+
+// EnsureAbsentRecord makes sure a DNS record does not exist. Every record with
+// the same name and type whose data matches r is removed.
+//
+// The field holding the data of the record type must be set: the target of
+// SRV, SVCB and HTTPS records, and the content of other records except dynamic
+// ones. The other data fields (priority, weight, port and SSH fields) are only
+// compared if they are set, so an MX record given without a priority matches
+// the record with that content whatever its priority. Use EnsureAbsentRRset to
+// remove every record with a name and type.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - r: The record that should not exist.
+//
+// Returns:
+//   - The Change made: ChangeDeleted or ChangeUnchanged.
+//   - A *ValidationError if the data field of r is empty, or an error if a
+//     request fails.
+func (c *RecordClient) EnsureAbsentRecord(ctx context.Context, r schema.RecordCreateParams) (Change, error) {
+	if field, value := recordDataField(r); field != "" && value == "" {
+		return "", &ValidationError{Type: r.Type, Name: r.Name, Errors: []*FieldError{
+			{Field: field, Value: value, Reason: "must not be empty; use EnsureAbsentRRset to remove every record"},
+		}}
+	}
+	existingRecords, err := c.ListRecords(ctx, r.Domain)
+	if err != nil {
+		return "", err
+	}
+
+	var matching []schema.RecordResponse
+	for _, record := range filterRRset(existingRecords, r.Name, RecordType(r.Type)) {
+		if recordDataMatches(r, recordCreateParams(r.Domain, record)) {
+			matching = append(matching, record)
+		}
+	}
+	return c.ensureRemoved(ctx, r.Domain, matching)
+}
+
+// EnsureAbsentRRset makes sure a domain has no records with the given name and
+// type, removing every one of them.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//   - name: The record name, e.g. "@" or "www".
+//   - recordType: The record type.
+//
+// Returns:
+//   - The Change made: ChangeDeleted or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *RecordClient) EnsureAbsentRRset(ctx context.Context, domain, name string, recordType RecordType) (Change, error) {
+	existingRecords, err := c.ListRecords(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+	return c.ensureRemoved(ctx, domain, filterRRset(existingRecords, name, recordType))
+}
+
+// ensureRemoved removes the given records of a domain.
+func (c *RecordClient) ensureRemoved(ctx context.Context, domain string, records []schema.RecordResponse) (Change, error) {
+	change := ChangeUnchanged
+	for _, record := range records {
+		params := schema.RecordDeleteParams{ID: record.ID, Domain: domain}
+		if _, err := c.client.call(ctx, "remove-record", params, nil); err != nil {
+			return change, err
+		}
+		change = ChangeDeleted
+	}
+	return change, nil
+}
+
+// recordDataField returns the name and value of the field holding the data of
+// a record of the type of r, or an empty name for types without data.
+func recordDataField(r schema.RecordCreateParams) (string, string) {
+	switch RecordType(r.Type) {
+	case RecordTypeSRV, RecordTypeSVCB, RecordTypeHTTPS:
+		return "target", r.Target
+	case RecordTypeDynamic:
+		return "", ""
+	}
+	return "content", r.Content
+}
+
+// recordDataMatches reports whether the data of record matches the data fields
+// set in r. Fields left at their zero value in r match any value.
+func recordDataMatches(r, record schema.RecordCreateParams) bool {
+	return fieldMatches(r.Content, record.Content) &&
+		fieldMatches(r.Prio, record.Prio) &&
+		fieldMatches(r.Weight, record.Weight) &&
+		fieldMatches(r.Port, record.Port) &&
+		fieldMatches(r.Target, record.Target) &&
+		fieldMatches(r.SSHAlgorithm, record.SSHAlgorithm) &&
+		fieldMatches(r.SSHType, record.SSHType)
+}
+
+// fieldMatches reports whether got equals want, or want is the zero value.
+func fieldMatches[T comparable](want, got T) bool {
+	var zero T
+	return want == zero || want == got
+}
+
+// EnsureForward makes sure a mail forward exists, creating it if needed.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - forwardParams: The forward that should exist.
+//
+// Returns:
+//   - The Change made: ChangeCreated or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *ForwardClient) EnsureForward(ctx context.Context, forwardParams schema.ForwardParams) (Change, error) {
+	existingForwards, err := c.ListForward(ctx, forwardParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	for _, forward := range existingForwards {
+		if forward.From == forwardParams.From && forward.To == forwardParams.To {
+			return ChangeUnchanged, nil
+		}
+	}
+	var created schema.ForwardCreateRequestResponse
+	if _, err := c.client.call(ctx, "add-forward", forwardParams, &created); err != nil {
+		return "", err
+	}
+	return ChangeCreated, nil
+}
+
+// EnsureAbsentForward makes sure a mail forward does not exist, removing it if needed.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - forwardParams: The forward that should not exist.
+//
+// Returns:
+//   - The Change made: ChangeDeleted or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *ForwardClient) EnsureAbsentForward(ctx context.Context, forwardParams schema.ForwardParams) (Change, error) {
+	existingForwards, err := c.ListForward(ctx, forwardParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	for _, forward := range existingForwards {
+		if forward.From == forwardParams.From && forward.To == forwardParams.To {
+			if _, err := c.client.call(ctx, "remove-forward", forwardParams, nil); err != nil {
+				return "", err
+			}
+			return ChangeDeleted, nil
+		}
+	}
+	return ChangeUnchanged, nil
+}
+
+// EnsureGlue makes sure a glue record exists with the given addresses. An
+// existing glue record with the same name but different addresses is updated.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - glueParams: The glue record that should exist.
+//
+// Returns:
+//   - The Change made: ChangeCreated, ChangeUpdated or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *GlueClient) EnsureGlue(ctx context.Context, glueParams schema.GlueParams) (Change, error) {
+	existingGlues, err := c.ListGlue(ctx, glueParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	for _, glue := range existingGlues {
+		if glue.Name != glueParams.Name {
+			continue
+		}
+		if glue.Address4 == glueParams.Address4 && glue.Address6 == glueParams.Address6 {
+			return ChangeUnchanged, nil
+		}
+		if _, err := c.client.call(ctx, "edit-glue", glueParams, nil); err != nil {
+			return "", err
+		}
+		return ChangeUpdated, nil
+	}
+	if _, err := c.client.call(ctx, "add-glue", glueParams, nil); err != nil {
+		return "", err
+	}
+	return ChangeCreated, nil
+}
+
+// EnsureAbsentGlue makes sure a glue record does not exist, removing it if needed.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - glueParams: The glue record that should not exist.
+//
+// Returns:
+//   - The Change made: ChangeDeleted or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *GlueClient) EnsureAbsentGlue(ctx context.Context, glueParams schema.GlueDeleteParams) (Change, error) {
+	existingGlues, err := c.ListGlue(ctx, glueParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	for _, glue := range existingGlues {
+		if glue.Name == glueParams.Name {
+			if _, err := c.client.call(ctx, "remove-glue", glueParams, nil); err != nil {
+				return "", err
+			}
+			return ChangeDeleted, nil
+		}
+	}
+	return ChangeUnchanged, nil
+}
+
+// EnsureDNSSEC makes sure a DNSSEC record with the given algorithm, digest,
+// digest type, key tag and public key exists, creating it if needed.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - dnssecParams: The DNSSEC record that should exist.
+//
+// Returns:
+//   - The Change made: ChangeCreated or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *DNSSECClient) EnsureDNSSEC(ctx context.Context, dnssecParams schema.DNSSECCreateParams) (Change, error) {
+	existingRecords, err := c.ListDNSSEC(ctx, dnssecParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	for _, record := range existingRecords {
+		if sameDNSSEC(record, dnssecParams) {
+			return ChangeUnchanged, nil
+		}
+	}
+	var created schema.DNSSECCreateRequestResponse
+	if _, err := c.client.call(ctx, "add-dnssec", dnssecParams, &created); err != nil {
+		return "", err
+	}
+	return ChangeCreated, nil
+}
+
+// EnsureAbsentDNSSEC makes sure no DNSSEC record with the given algorithm,
+// digest, digest type, key tag and public key exists, removing it if needed.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - dnssecParams: The DNSSEC record that should not exist.
+//
+// Returns:
+//   - The Change made: ChangeDeleted or ChangeUnchanged.
+//   - An error if a request fails.
+func (c *DNSSECClient) EnsureAbsentDNSSEC(ctx context.Context, dnssecParams schema.DNSSECCreateParams) (Change, error) {
+	existingRecords, err := c.ListDNSSEC(ctx, dnssecParams.Domain)
+	if err != nil {
+		return "", err
+	}
+	change := ChangeUnchanged
+	for _, record := range existingRecords {
+		if !sameDNSSEC(record, dnssecParams) {
+			continue
+		}
+		params := schema.DNSSECDeleteParams{Domain: dnssecParams.Domain, ID: record.ID}
+		if _, err := c.client.call(ctx, "remove-dnssec", params, nil); err != nil {
+			return change, err
+		}
+		change = ChangeDeleted
+	}
+	return change, nil
+}
@@ -0,0 +1,284 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// ensureRecords are the records every Ensure test starts from.
+var ensureRecords = []schema.RecordResponse{
+	{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+	{Name: "@", Type: "MX", Content: "mx1.example.com", Prio: 10, TTL: 3600},
+	{Name: "@", Type: "MX", Content: "mx2.example.com", Prio: 20, TTL: 3600},
+	{Name: "blog", Type: "CNAME", Content: "example.net", TTL: 3600},
+	{Name: "_sip._tcp", Type: "SRV", Prio: 10, Weight: 5, Port: 5060, Target: "sip.example.com", TTL: 3600},
+}
+
+// rrsetData returns the priority, weight, port, target and content of the
+// records with the given name and type, sorted.
+func rrsetData(records []schema.RecordResponse, name, recordType string) []string {
+	var values []string
+	for _, r := range records {
+		if r.Name == name && r.Type == recordType {
+			values = append(values, fmt.Sprintf("%d %d %d %s%s", r.Prio, r.Weight, r.Port, r.Target, r.Content))
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+func TestEnsureRecord(t *testing.T) {
+	tests := []struct {
+		name       string
+		record     schema.RecordCreateParams
+		wantChange client.Change
+		wantSet    []string
+	}{
+		{"exists", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.1"}, client.ChangeUnchanged, []string{"192.0.2.1"}},
+		{"exists with the same TTL", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.1", TTL: 3600}, client.ChangeUnchanged, []string{"192.0.2.1"}},
+		{"TTL differs", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.1", TTL: 300}, client.ChangeUpdated, []string{"192.0.2.1"}},
+		{"added to the RRset", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.2"}, client.ChangeCreated, []string{"192.0.2.1", "192.0.2.2"}},
+		{"CNAME updated in place", schema.RecordCreateParams{Type: "CNAME", Name: "blog", Content: "example.org"}, client.ChangeUpdated, []string{"example.org"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, ensureRecords...)
+			c := srv.Client()
+			tt.record.Domain = "example.com"
+
+			record, change, err := c.Record.EnsureRecord(context.Background(), tt.record)
+			if err != nil {
+				t.Fatalf("EnsureRecord() error = %v", err)
+			}
+			if change != tt.wantChange {
+				t.Errorf("EnsureRecord() change = %q, want %q", change, tt.wantChange)
+			}
+			if record.Content != tt.record.Content {
+				t.Errorf("EnsureRecord() record content = %q, want %q", record.Content, tt.record.Content)
+			}
+			if got := contents(srv.Records("example.com"), tt.record.Name, tt.record.Type); !slices.Equal(got, tt.wantSet) {
+				t.Errorf("stored records = %v, want %v", got, tt.wantSet)
+			}
+			if tt.wantChange == client.ChangeUnchanged && len(srv.RequestsFor("edit-record"))+len(srv.RequestsFor("add-record")) > 0 {
+				t.Error("EnsureRecord() changed an existing record")
+			}
+		})
+	}
+}
+
+func TestEnsureRecordConvergesMutableFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		record     schema.RecordCreateParams
+		wantChange client.Change
+		wantSet    []string
+	}{
+		{
+			"MX priority updated in place",
+			schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx1.example.com", Prio: 30},
+			client.ChangeUpdated,
+			[]string{"20 0 0 mx2.example.com", "30 0 0 mx1.example.com"},
+		},
+		{
+			"MX priority set to zero",
+			schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx2.example.com"},
+			client.ChangeUpdated,
+			[]string{"0 0 0 mx2.example.com", "10 0 0 mx1.example.com"},
+		},
+		{
+			"MX with the same priority",
+			schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx1.example.com", Prio: 10},
+			client.ChangeUnchanged,
+			[]string{"10 0 0 mx1.example.com", "20 0 0 mx2.example.com"},
+		},
+		{
+			"SRV weight updated in place",
+			schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Prio: 10, Weight: 7, Port: 5060, Target: "sip.example.com"},
+			client.ChangeUpdated,
+			[]string{"10 7 5060 sip.example.com"},
+		},
+		{
+			"SRV priority updated in place",
+			schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Prio: 20, Weight: 5, Port: 5060, Target: "sip.example.com"},
+			client.ChangeUpdated,
+			[]string{"20 5 5060 sip.example.com"},
+		},
+		{
+			"SRV on another port added",
+			schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Prio: 10, Weight: 5, Port: 5061, Target: "sip.example.com"},
+			client.ChangeCreated,
+			[]string{"10 5 5060 sip.example.com", "10 5 5061 sip.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, ensureRecords...)
+			c := srv.Client()
+			tt.record.Domain = "example.com"
+
+			_, change, err := c.Record.EnsureRecord(context.Background(), tt.record)
+			if err != nil {
+				t.Fatalf("EnsureRecord() error = %v", err)
+			}
+			if change != tt.wantChange {
+				t.Errorf("EnsureRecord() change = %q, want %q", change, tt.wantChange)
+			}
+			if got := rrsetData(srv.Records("example.com"), tt.record.Name, tt.record.Type); !slices.Equal(got, tt.wantSet) {
+				t.Errorf("stored records = %v, want %v", got, tt.wantSet)
+			}
+
+			// An update sends every field the type uses, zero values included.
+			for _, request := range srv.RequestsFor("edit-record") {
+				var params map[string]json.RawMessage
+				if err := json.Unmarshal(request.Params, &params); err != nil {
+					t.Fatalf("failed to decode params: %v", err)
+				}
+				want := []string{"prio"}
+				if tt.record.Type == "SRV" {
+					want = []string{"prio", "weight", "port", "target"}
+				}
+				for _, field := range want {
+					if _, ok := params[field]; !ok {
+						t.Errorf("edit-record params %s lack %q", request.Params, field)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEnsureAbsentRecord(t *testing.T) {
+	tests := []struct {
+		name       string
+		record     schema.RecordCreateParams
+		wantChange client.Change
+		wantSet    []string
+	}{
+		{"exact match", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx1.example.com", Prio: 10}, client.ChangeDeleted, []string{"mx2.example.com"}},
+		{"MX without priority", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx1.example.com"}, client.ChangeDeleted, []string{"mx2.example.com"}},
+		{"MX with another priority", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx1.example.com", Prio: 20}, client.ChangeUnchanged, []string{"mx1.example.com", "mx2.example.com"}},
+		{"absent", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mx3.example.com"}, client.ChangeUnchanged, []string{"mx1.example.com", "mx2.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, ensureRecords...)
+			c := srv.Client()
+			tt.record.Domain = "example.com"
+
+			change, err := c.Record.EnsureAbsentRecord(context.Background(), tt.record)
+			if err != nil {
+				t.Fatalf("EnsureAbsentRecord() error = %v", err)
+			}
+			if change != tt.wantChange {
+				t.Errorf("EnsureAbsentRecord() change = %q, want %q", change, tt.wantChange)
+			}
+			if got := contents(srv.Records("example.com"), tt.record.Name, tt.record.Type); !slices.Equal(got, tt.wantSet) {
+				t.Errorf("stored records = %v, want %v", got, tt.wantSet)
+			}
+			if got := contents(srv.Records("example.com"), "www", "A"); len(got) != 1 {
+				t.Errorf("A records = %v, want them untouched", got)
+			}
+		})
+	}
+}
+
+func TestEnsureAbsentRecordRequiresData(t *testing.T) {
+	tests := []struct {
+		name      string
+		record    schema.RecordCreateParams
+		wantField string
+	}{
+		{"MX priority only", schema.RecordCreateParams{Type: "MX", Name: "@", Prio: 20}, "content"},
+		{"MX without data", schema.RecordCreateParams{Type: "MX", Name: "@"}, "content"},
+		{"SRV without target", schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Port: 5060}, "target"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, ensureRecords...)
+			c := srv.Client()
+			tt.record.Domain = "example.com"
+
+			_, err := c.Record.EnsureAbsentRecord(context.Background(), tt.record)
+			var fieldErr *client.FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantField {
+				t.Fatalf("EnsureAbsentRecord() error = %v, want a FieldError for %q", err, tt.wantField)
+			}
+			if got := len(srv.Records("example.com")); got != len(ensureRecords) {
+				t.Errorf("stored records = %d, want %d", got, len(ensureRecords))
+			}
+		})
+	}
+}
+
+func TestEnsureAbsentRRset(t *testing.T) {
+	srv := newRecordServer(t, ensureRecords...)
+	c := srv.Client()
+	ctx := context.Background()
+
+	change, err := c.Record.EnsureAbsentRRset(ctx, "example.com", "@", client.RecordTypeMX)
+	if err != nil {
+		t.Fatalf("EnsureAbsentRRset() error = %v", err)
+	}
+	if change != client.ChangeDeleted {
+		t.Errorf("EnsureAbsentRRset() change = %q, want %q", change, client.ChangeDeleted)
+	}
+	if got := contents(srv.Records("example.com"), "@", "MX"); len(got) != 0 {
+		t.Errorf("MX records = %v, want none", got)
+	}
+	if got := len(srv.Records("example.com")); got != len(ensureRecords)-2 {
+		t.Errorf("stored records = %d, want the other %d untouched", got, len(ensureRecords)-2)
+	}
+
+	change, err = c.Record.EnsureAbsentRRset(ctx, "example.com", "@", client.RecordTypeMX)
+	if err != nil || change != client.ChangeUnchanged {
+		t.Errorf("second EnsureAbsentRRset() = %q, %v, want %q", change, err, client.ChangeUnchanged)
+	}
+}
+
+func TestEnsureForwardAndGlue(t *testing.T) {
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com"}}})
+	c := srv.Client()
+	ctx := context.Background()
+
+	forward := schema.ForwardParams{Domain: "example.com", From: "hello", To: "alice@example.net"}
+	glue := schema.GlueParams{Domain: "example.com", Name: "ns1", Address4: "192.0.2.53"}
+	movedGlue := schema.GlueParams{Domain: "example.com", Name: "ns1", Address4: "192.0.2.54"}
+	steps := []struct {
+		name   string
+		ensure func() (client.Change, error)
+		want   client.Change
+	}{
+		{"create forward", func() (client.Change, error) { return c.Forward.EnsureForward(ctx, forward) }, client.ChangeCreated},
+		{"keep forward", func() (client.Change, error) { return c.Forward.EnsureForward(ctx, forward) }, client.ChangeUnchanged},
+		{"remove forward", func() (client.Change, error) { return c.Forward.EnsureAbsentForward(ctx, forward) }, client.ChangeDeleted},
+		{"forward already absent", func() (client.Change, error) { return c.Forward.EnsureAbsentForward(ctx, forward) }, client.ChangeUnchanged},
+		{"create glue", func() (client.Change, error) { return c.Glue.EnsureGlue(ctx, glue) }, client.ChangeCreated},
+		{"keep glue", func() (client.Change, error) { return c.Glue.EnsureGlue(ctx, glue) }, client.ChangeUnchanged},
+		{"update glue", func() (client.Change, error) { return c.Glue.EnsureGlue(ctx, movedGlue) }, client.ChangeUpdated},
+		{"remove glue", func() (client.Change, error) {
+			return c.Glue.EnsureAbsentGlue(ctx, schema.GlueDeleteParams{Domain: "example.com", Name: "ns1"})
+		}, client.ChangeDeleted},
+	}
+	for _, step := range steps {
+		change, err := step.ensure()
+		if err != nil {
+			t.Fatalf("%s: error = %v", step.name, err)
+		}
+		if change != step.want {
+			t.Errorf("%s: change = %q, want %q", step.name, change, step.want)
+		}
+	}
+	if forwards, glue := srv.Forwards("example.com"), srv.Glue("example.com"); len(forwards) != 0 || len(glue) != 0 {
+		t.Errorf("forwards, glue = %v, %v, want none left", forwards, glue)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError describes a failed call to the Njalla API. It is returned by
+// DoRequest whenever the server answers with a JSON-RPC "error" object or a
+// non-200 HTTP status code.
+//
+// Fields:
+//   - Code: The error code from the JSON-RPC "error" object, or 0 if none was sent.
+//   - Message: The error message from the JSON-RPC "error" object, or the HTTP
+//     status text if the body did not contain one.
+//   - StatusCode: The HTTP status code of the response.
+//   - Method: The JSON-RPC method that was called, e.g. "add-record".
+//
+// Example usage:
+//
+//	var apiErr *client.APIError
+//	if errors.As(err, &apiErr) {
+//	    log.Printf("%s failed with code %d", apiErr.Method, apiErr.Code)
+//	}
+type APIError struct {
+	Code       int
+	Message    string
+	StatusCode int
+	Method     string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	switch {
+	case e.Method != "" && e.Code != 0:
+		return fmt.Sprintf("njalla: %s: %s (code %d, status %d)", e.Method, msg, e.Code, e.StatusCode)
+	case e.Method != "":
+		return fmt.Sprintf("njalla: %s: %s (status %d)", e.Method, msg, e.StatusCode)
+	default:
+		return fmt.Sprintf("njalla: %s (status %d)", msg, e.StatusCode)
+	}
+}
+
+// hasCode reports whether the error carries any of the given codes, either as
+// its JSON-RPC error code or as its HTTP status code.
+func (e *APIError) hasCode(codes ...int) bool {
+	for _, code := range codes {
+		if e.Code == code || e.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Sentinel errors returned by the existence and duplicate checks the sub-clients
+// run before sending a request. They are wrapped in a *ResourceError carrying
+// the domain and identifier of the resource, and can be tested with errors.Is.
+var (
+	ErrDomainNotFound  = errors.New("domain not found")
+	ErrRecordExists    = errors.New("record already exists")
+	ErrRecordNotFound  = errors.New("record not found")
+	ErrForwardExists   = errors.New("forward already exists")
+	ErrForwardNotFound = errors.New("forward not found")
+	ErrGlueExists      = errors.New("glue record already exists")
+	ErrGlueNotFound    = errors.New("glue record not found")
+	ErrDNSSECExists    = errors.New("DNSSEC record already exists")
+	ErrDNSSECNotFound  = errors.New("DNSSEC record not found")
+	ErrVPNNotFound     = errors.New("VPN not found")
+	ErrTokenNotFound   = errors.New("API token not found")
+	ErrServerNotFound  = errors.New("server not found")
+)
+
+// notFoundErrors lists the sentinel errors reported by IsNotFound.
+var notFoundErrors = []error{ErrDomainNotFound, ErrRecordNotFound, ErrForwardNotFound, ErrGlueNotFound, ErrDNSSECNotFound, ErrVPNNotFound, ErrTokenNotFound, ErrServerNotFound}
+
+// ResourceError is returned when a pre-flight check fails because a resource
+// is missing or already exists. It wraps one of the sentinel errors such as
+// ErrRecordNotFound.
+//
+// Fields:
+//   - Err: The sentinel error describing the failure.
+//   - Domain: The domain the resource belongs to, or empty for resources such
+//     as VPNs and servers that do not belong to a domain.
+//   - ID: The identifier of the resource, e.g. a record ID, a glue record name,
+//     or the addresses of a forward.
+//
+// Example usage:
+//
+//	_, err := c.Record.DeleteRecord(ctx, params)
+//	if errors.Is(err, client.ErrRecordNotFound) {
+//	    // already gone
+//	}
+type ResourceError struct {
+	Err    error
+	Domain string
+	ID     string
+}
+
+// Error implements the error interface.
+func (e *ResourceError) Error() string {
+	if e.Domain == "" {
+		return fmt.Sprintf("%v: %s", e.Err, e.ID)
+	}
+	if e.ID == "" || e.ID == e.Domain {
+		return fmt.Sprintf("%v: %s", e.Err, e.Domain)
+	}
+	return fmt.Sprintf("%v: %s in domain %s", e.Err, e.ID, e.Domain)
+}
+
+// Unwrap returns the sentinel error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err indicates that the requested resource does
+// not exist, either as an APIError or as one of the not found sentinel errors.
+func IsNotFound(err error) bool {
+	for _, target := range notFoundErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.hasCode(http.StatusNotFound)
+}
+
+// IsPermissionDenied reports whether err is an APIError indicating that the
+// API key is missing, invalid, or not allowed to perform the request.
+func IsPermissionDenied(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.hasCode(http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is an APIError indicating that the client
+// has sent too many requests.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.hasCode(http.StatusTooManyRequests)
+}
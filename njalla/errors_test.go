@@ -0,0 +1,225 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		want     *client.APIError
+		wantText string
+		check    func(error) bool
+	}{
+		{
+			name:     "JSON-RPC error",
+			status:   http.StatusOK,
+			body:     `{"jsonrpc":"2.0","error":{"code":404,"message":"domain not found"}}`,
+			want:     &client.APIError{Code: 404, Message: "domain not found", StatusCode: http.StatusOK, Method: "list-domains"},
+			wantText: "njalla: list-domains: domain not found (code 404, status 200)",
+			check:    client.IsNotFound,
+		},
+		{
+			name:     "JSON-RPC error with error status",
+			status:   http.StatusForbidden,
+			body:     `{"jsonrpc":"2.0","error":{"code":403,"message":"permission denied"}}`,
+			want:     &client.APIError{Code: 403, Message: "permission denied", StatusCode: http.StatusForbidden, Method: "list-domains"},
+			wantText: "njalla: list-domains: permission denied (code 403, status 403)",
+			check:    client.IsPermissionDenied,
+		},
+		{
+			name:     "error status without body",
+			status:   http.StatusTooManyRequests,
+			want:     &client.APIError{StatusCode: http.StatusTooManyRequests, Method: "list-domains"},
+			wantText: "njalla: list-domains: Too Many Requests (status 429)",
+			check:    client.IsRateLimited,
+		},
+		{
+			name:     "error status with a result",
+			status:   http.StatusUnauthorized,
+			body:     `{"jsonrpc":"2.0","result":{"domains":[]}}`,
+			want:     &client.APIError{StatusCode: http.StatusUnauthorized, Method: "list-domains"},
+			wantText: "njalla: list-domains: Unauthorized (status 401)",
+			check:    client.IsPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+			c := client.NewClient(client.WithEndpoint(srv.URL), client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+
+			_, err := c.Domain.ListDomains(context.Background())
+			var apiErr *client.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("ListDomains() error = %v, want an *APIError", err)
+			}
+			if *apiErr != *tt.want {
+				t.Errorf("APIError = %+v, want %+v", *apiErr, *tt.want)
+			}
+			if got := err.Error(); got != tt.wantText {
+				t.Errorf("Error() = %q, want %q", got, tt.wantText)
+			}
+			if !tt.check(err) {
+				t.Errorf("predicate for %v = false", err)
+			}
+		})
+	}
+}
+
+func TestResponseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid JSON", `not json`},
+		{"missing result", `{"jsonrpc":"2.0"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+			c := client.NewClient(client.WithEndpoint(srv.URL))
+
+			_, err := c.Domain.ListDomains(context.Background())
+			var apiErr *client.APIError
+			if err == nil || errors.As(err, &apiErr) {
+				t.Errorf("ListDomains() error = %v, want an error that is not an *APIError", err)
+			}
+		})
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		notFound    bool
+		denied      bool
+		rateLimited bool
+	}{
+		{"not found code", &client.APIError{Code: 404, StatusCode: http.StatusOK}, true, false, false},
+		{"unauthorized status", &client.APIError{StatusCode: http.StatusUnauthorized}, false, true, false},
+		{"forbidden code", &client.APIError{Code: 403, StatusCode: http.StatusOK}, false, true, false},
+		{"rate limited status", &client.APIError{StatusCode: http.StatusTooManyRequests}, false, false, true},
+		{"wrapped", fmt.Errorf("listing: %w", &client.APIError{Code: 404}), true, false, false},
+		{"other code", &client.APIError{Code: 500, StatusCode: http.StatusOK}, false, false, false},
+		{"plain error", errors.New("boom"), false, false, false},
+		{"nil", nil, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound() = %t, want %t", got, tt.notFound)
+			}
+			if got := client.IsPermissionDenied(tt.err); got != tt.denied {
+				t.Errorf("IsPermissionDenied() = %t, want %t", got, tt.denied)
+			}
+			if got := client.IsRateLimited(tt.err); got != tt.rateLimited {
+				t.Errorf("IsRateLimited() = %t, want %t", got, tt.rateLimited)
+			}
+		})
+	}
+}
+
+func TestResourceErrors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		call     func(c *client.Client) error
+		wantErr  error
+		want     client.ResourceError
+		wantText string
+	}{
+		{"edit unknown domain", func(c *client.Client) error {
+			_, err := c.Domain.EditDomain(ctx, "example.org", false, false, true)
+			return err
+		}, client.ErrDomainNotFound, client.ResourceError{Domain: "example.org"}, "domain not found: example.org"},
+		{"create existing record", func(c *client.Client) error {
+			_, err := c.Record.CreateRecord(ctx, schema.RecordCreateParams{Domain: "example.com", Name: "www", Type: "A", Content: "192.0.2.1"})
+			return err
+		}, client.ErrRecordExists, client.ResourceError{Domain: "example.com", ID: "www A 192.0.2.1"}, "record already exists: www A 192.0.2.1 in domain example.com"},
+		{"update missing record", func(c *client.Client) error {
+			_, err := c.Record.UpdateRecord(ctx, schema.RecordUpdateParams{ID: "42", Domain: "example.com", Name: "www", Type: "A", Content: "192.0.2.2"})
+			return err
+		}, client.ErrRecordNotFound, client.ResourceError{Domain: "example.com", ID: "42"}, "record not found: 42 in domain example.com"},
+		{"delete missing record", func(c *client.Client) error {
+			_, err := c.Record.DeleteRecord(ctx, schema.RecordDeleteParams{ID: "42", Domain: "example.com"})
+			return err
+		}, client.ErrRecordNotFound, client.ResourceError{Domain: "example.com", ID: "42"}, "record not found: 42 in domain example.com"},
+		{"create existing forward", func(c *client.Client) error {
+			_, err := c.Forward.CreateForward(ctx, schema.ForwardParams{Domain: "example.com", From: "info", To: "alice@example.org"})
+			return err
+		}, client.ErrForwardExists, client.ResourceError{Domain: "example.com", ID: "info -> alice@example.org"}, "forward already exists: info -> alice@example.org in domain example.com"},
+		{"delete missing forward", func(c *client.Client) error {
+			_, err := c.Forward.DeleteForward(ctx, schema.ForwardParams{Domain: "example.com", From: "sales", To: "bob@example.org"})
+			return err
+		}, client.ErrForwardNotFound, client.ResourceError{Domain: "example.com", ID: "sales -> bob@example.org"}, "forward not found: sales -> bob@example.org in domain example.com"},
+		{"create existing glue", func(c *client.Client) error {
+			_, err := c.Glue.CreateGlue(ctx, schema.GlueParams{Domain: "example.com", Name: "ns1", Address4: "192.0.2.53"})
+			return err
+		}, client.ErrGlueExists, client.ResourceError{Domain: "example.com", ID: "ns1"}, "glue record already exists: ns1 in domain example.com"},
+		{"update missing glue", func(c *client.Client) error {
+			_, err := c.Glue.UpdateGlue(ctx, schema.GlueParams{Domain: "example.com", Name: "ns2", Address4: "192.0.2.54"})
+			return err
+		}, client.ErrGlueNotFound, client.ResourceError{Domain: "example.com", ID: "ns2"}, "glue record not found: ns2 in domain example.com"},
+		{"delete missing glue", func(c *client.Client) error {
+			_, err := c.Glue.DeleteGlue(ctx, schema.GlueDeleteParams{Domain: "example.com", Name: "ns2"})
+			return err
+		}, client.ErrGlueNotFound, client.ResourceError{Domain: "example.com", ID: "ns2"}, "glue record not found: ns2 in domain example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := njallatest.NewServer()
+			defer srv.Close()
+			srv.Seed(njallatest.Fixture{
+				Domains:  []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+				Records:  map[string][]schema.RecordResponse{"example.com": {{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600}}},
+				Forwards: map[string][]schema.ForwardResponse{"example.com": {{From: "info", To: "alice@example.org"}}},
+				Glue:     map[string][]schema.GlueResponse{"example.com": {{Name: "ns1", Address4: "192.0.2.53"}}},
+			})
+
+			err := tt.call(srv.Client())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var resourceErr *client.ResourceError
+			if !errors.As(err, &resourceErr) {
+				t.Fatalf("error = %#v, want a *ResourceError", err)
+			}
+			tt.want.Err = tt.wantErr
+			if *resourceErr != tt.want {
+				t.Errorf("ResourceError = %+v, want %+v", *resourceErr, tt.want)
+			}
+			if got := err.Error(); got != tt.wantText {
+				t.Errorf("Error() = %q, want %q", got, tt.wantText)
+			}
+			if got, want := client.IsNotFound(err), strings.HasSuffix(tt.wantErr.Error(), "not found"); got != want {
+				t.Errorf("IsNotFound() = %t, want %t", got, want)
+			}
+			// The pre-flight check fails before anything is changed.
+			for _, request := range srv.Requests() {
+				if !strings.HasPrefix(request.Method, "list-") {
+					t.Errorf("%s was sent after the check failed", request.Method)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// Logger returns the logger of c as wrapped by NewClient, so that tests can log
+// through the redacting handler directly.
+func Logger(c *Client) *slog.Logger {
+	return c.logger
+}
+
+// HTTPClient returns the HTTP client built by NewClient, so that tests can
+// check how the options were applied.
+func HTTPClient(c *Client) *http.Client {
+	return c.httpClient
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ajquack/njalla-dns-go/njalla/schema"
 )
@@ -11,6 +10,11 @@ type ForwardClient struct {
 	client *Client
 }
 
+// forwardID identifies a forward in errors.
+func forwardID(forwardParams schema.ForwardParams) string {
+	return forwardParams.From + " -> " + forwardParams.To
+}
+
 // ListForward retrieves a list of forward configurations for a specified domain.
 // It sends a request to the API with the provided domain and returns the list
 // of forward responses or an error if the operation fails.
@@ -26,17 +30,10 @@ func (c *ForwardClient) ListForward(ctx context.Context, domain string) ([]schem
 	const method string = "list-forwards"
 	var responseScheme schema.ForwardListRequestResponse
 
-	body := schema.ForwardListRequest{
-		Method: method,
-		Params: schema.ForwardListParams{
-			Domain: domain,
-		},
+	params := schema.ForwardListParams{
+		Domain: domain,
 	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -69,19 +66,11 @@ func (c *ForwardClient) CreateForward(ctx context.Context, forwardParams schema.
 	}
 	for _, forward := range existingForwards {
 		if forward.To == forwardParams.To && forward.From == forwardParams.From {
-			return nil, fmt.Errorf("Forward record from %s to %s already exists", forwardParams.To, forwardParams.From)
+			return nil, &ResourceError{Err: ErrForwardExists, Domain: forwardParams.Domain, ID: forwardID(forwardParams)}
 		}
 	}
 
-	body := schema.ForwardCreateRequest{
-		Method: method,
-		Params: forwardParams,
-	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, forwardParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +93,7 @@ func (c *ForwardClient) CreateForward(ctx context.Context, forwardParams schema.
 //   - An error if the forward record does not exist or if there is an issue with the request.
 //
 // Errors:
-//   - Returns an error if the forward record does not exist.
+//   - Returns a *ResourceError wrapping ErrForwardNotFound if the forward record does not exist.
 //   - Returns an error if there is an issue creating or executing the request.
 func (c *ForwardClient) DeleteForward(ctx context.Context, forwardParams schema.ForwardParams) (*schema.ForwardDeleteRequestResponse, error) {
 	const method string = "remove-forward"
@@ -124,18 +113,10 @@ func (c *ForwardClient) DeleteForward(ctx context.Context, forwardParams schema.
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("forward record from %s to %s does not exists", forwardParams.To, forwardParams.From)
+		return nil, &ResourceError{Err: ErrForwardNotFound, Domain: forwardParams.Domain, ID: forwardID(forwardParams)}
 	}
 
-	body := schema.ForwardDeleteRequest{
-		Method: method,
-		Params: forwardParams,
-	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, forwardParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
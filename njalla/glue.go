@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ajquack/njalla-dns-go/njalla/schema"
 )
@@ -27,17 +26,10 @@ func (c *GlueClient) ListGlue(ctx context.Context, domain string) ([]schema.Glue
 	const method string = "list-glue"
 	var responseScheme schema.GlueListRequestResponse
 
-	body := schema.GlueListRequest{
-		Method: method,
-		Params: schema.GlueListParams{
-			Domain: domain,
-		},
+	params := schema.GlueListParams{
+		Domain: domain,
 	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -70,19 +62,11 @@ func (c *GlueClient) CreateGlue(ctx context.Context, glueParams schema.GlueParam
 	}
 	for _, glue := range existingGlues {
 		if glue.Name == glueParams.Name {
-			return nil, fmt.Errorf("Glue record %s already exists", glueParams.Name)
+			return nil, &ResourceError{Err: ErrGlueExists, Domain: glueParams.Domain, ID: glueParams.Name}
 		}
 	}
 
-	body := schema.GlueCreateRequest{
-		Method: method,
-		Params: glueParams,
-	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, glueParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -120,21 +104,10 @@ func (c *GlueClient) UpdateGlue(ctx context.Context, glueParams schema.GlueParam
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("glue record with ID %s does not exist", glueParams.Name)
+		return nil, &ResourceError{Err: ErrGlueNotFound, Domain: glueParams.Domain, ID: glueParams.Name}
 	}
 
-	// Create request body
-	body := schema.GlueUpdateRequest{
-		Method: method,
-		Params: glueParams,
-	}
-	// Create request
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	// Send request
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, glueParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +130,7 @@ func (c *GlueClient) UpdateGlue(ctx context.Context, glueParams schema.GlueParam
 //   - An error if the glue record does not exist or if there is an issue with the request.
 //
 // Errors:
-//   - Returns an error if the glue record with the specified name does not exist.
+//   - Returns a *ResourceError wrapping ErrGlueNotFound if the glue record with the specified name does not exist.
 //   - Returns an error if there is an issue creating or sending the request.
 func (c *GlueClient) DeleteGlue(ctx context.Context, glueParams schema.GlueDeleteParams) (*schema.GlueDeleteRequestResponse, error) {
 	const method string = "remove-glue"
@@ -177,21 +150,10 @@ func (c *GlueClient) DeleteGlue(ctx context.Context, glueParams schema.GlueDelet
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("glue record with name %s does not exist", glueParams.Name)
+		return nil, &ResourceError{Err: ErrGlueNotFound, Domain: glueParams.Domain, ID: glueParams.Name}
 	}
 
-	// Create request body
-	body := schema.GlueDeleteRequest{
-		Method: method,
-		Params: glueParams,
-	}
-	// Create request
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	// Send request
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, glueParams, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
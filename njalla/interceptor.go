@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Invoker performs a single JSON-RPC call. The "result" field of the response is
+// unmarshaled into v, and the unmarshaled value is returned.
+type Invoker func(ctx context.Context, method string, params any, v any) (any, error)
+
+// Interceptor wraps every JSON-RPC call made by the sub-clients.
+//
+// An interceptor receives the RPC method name (e.g. "add-record"), the params and
+// the response target of the call, and a next function that continues the chain.
+// It may inspect or replace any of them, return early without calling next, or
+// post-process the result and error returned by next.
+//
+// Calls queued in a Batch pass through the chain as a single call with the
+// method "batch", whose params are a []BatchRequest listing the queued calls.
+// If the endpoint does not accept batch requests, the queued calls then pass
+// through the chain one by one.
+//
+// Example usage:
+//
+//	timing := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+//	    start := time.Now()
+//	    resp, err := next(ctx, method, params, v)
+//	    log.Printf("%s took %s", method, time.Since(start))
+//	    return resp, err
+//	}
+//	c := client.NewClient(client.APIKey(key), client.WithInterceptors(timing))
+type Interceptor func(ctx context.Context, method string, params any, v any, next Invoker) (any, error)
+
+// WithInterceptors adds interceptors to the client. Interceptors run in the order
+// they are added, the first one being the outermost. The option may be used
+// more than once.
+//
+// Parameters:
+//   - interceptors: The interceptors to add.
+//
+// Returns:
+//
+//	A ClientOption that adds the specified interceptors to a Client instance.
+func WithInterceptors(interceptors ...Interceptor) ClientOption {
+	return func(client *Client) {
+		client.interceptors = append(client.interceptors, interceptors...)
+	}
+}
+
+type apiKeyContextKey struct{}
+
+// ContextWithAPIKey returns a copy of ctx that makes requests sent with it use
+// the given API key instead of the client's own. Interceptors can use it to
+// rewrite the credentials of individual calls.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - apiKey: The API key to use for requests made with the returned context.
+//
+// Returns:
+//   - A context carrying the API key.
+func ContextWithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, apiKey)
+}
+
+// contextAPIKey returns the API key stored in ctx by ContextWithAPIKey.
+func contextAPIKey(ctx context.Context) (string, bool) {
+	apiKey, ok := ctx.Value(apiKeyContextKey{}).(string)
+	return apiKey, ok
+}
+
+// rpcRequest is the JSON-RPC envelope sent to the API.
+type rpcRequest struct {
+	Method string `json:"method"`
+	Params any    `json:"params"`
+}
+
+// call performs a JSON-RPC call through the client's interceptor chain.
+func (c *Client) call(ctx context.Context, method string, params any, v any) (any, error) {
+	invoke := c.invoke
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], invoke
+		invoke = func(ctx context.Context, method string, params any, v any) (any, error) {
+			return interceptor(ctx, method, params, v, next)
+		}
+	}
+	return invoke(ctx, method, params, v)
+}
+
+// invoke is the innermost Invoker. It sends the call to the API and logs its outcome.
+func (c *Client) invoke(ctx context.Context, method string, params any, v any) (any, error) {
+	if method == "" {
+		return nil, errors.New("missing RPC method")
+	}
+	start := time.Now()
+	ctx, attempts := withAttemptCounter(ctx)
+	var body any = rpcRequest{Method: method, Params: params}
+	entries, isBatch := params.([]BatchRequest)
+	if isBatch && method == batchMethod {
+		body = entries
+	}
+	req, err := c.NewRequest(ctx, body)
+	if err != nil {
+		c.logCall(ctx, method, params, start, attempts.Load(), err)
+		return nil, err
+	}
+	var resp any
+	if isBatch && method == batchMethod {
+		resp, err = c.doBatchRequest(req, v)
+	} else {
+		resp, err = c.DoRequest(req, v)
+	}
+	c.logCall(ctx, method, params, start, attempts.Load(), err)
+	return resp, err
+}
@@ -0,0 +1,102 @@
+package client_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestInterceptorOrder(t *testing.T) {
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com"}}})
+
+	var events []string
+	trace := func(name string) client.Interceptor {
+		return func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+			events = append(events, name+" before "+method)
+			resp, err := next(ctx, method, params, v)
+			events = append(events, name+" after "+method)
+			return resp, err
+		}
+	}
+	// The second interceptor short-circuits get-domain, so the third never
+	// sees it.
+	shortCircuit := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+		if method == "get-domain" {
+			events = append(events, "short-circuit "+method)
+			return &schema.GetDomainRequestResponse{Name: "cached.example"}, nil
+		}
+		return next(ctx, method, params, v)
+	}
+	c := srv.Client(
+		client.WithInterceptors(trace("outer"), shortCircuit),
+		client.WithInterceptors(trace("inner")),
+	)
+	ctx := context.Background()
+
+	if _, err := c.Domain.ListDomains(ctx); err != nil {
+		t.Fatalf("ListDomains() error = %v", err)
+	}
+	domain, err := c.Domain.GetDomain(ctx, schema.GetDomainParams{Domain: "example.com"})
+	if err != nil {
+		t.Fatalf("GetDomain() error = %v", err)
+	}
+	if domain.Name != "cached.example" {
+		t.Errorf("GetDomain() = %+v, want the short-circuited response", domain)
+	}
+
+	want := []string{
+		"outer before list-domains",
+		"inner before list-domains",
+		"inner after list-domains",
+		"outer after list-domains",
+		"outer before get-domain",
+		"short-circuit get-domain",
+		"outer after get-domain",
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %q, want %q", events, want)
+	}
+	if got := len(srv.RequestsFor("get-domain")); got != 0 {
+		t.Errorf("get-domain requests = %d, want 0", got)
+	}
+}
+
+func TestInterceptorsSeeBatchedCalls(t *testing.T) {
+	// The fake server rejects batch requests, so the queued calls pass through
+	// the chain once as a batch and then one by one.
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com"}}})
+
+	var seen []string
+	interceptor := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+		if entries, ok := params.([]client.BatchRequest); ok {
+			for _, entry := range entries {
+				seen = append(seen, method+"/"+entry.Method)
+			}
+		} else {
+			seen = append(seen, method)
+		}
+		return next(ctx, method, params, v)
+	}
+	c := srv.Client(client.WithInterceptors(interceptor))
+
+	_, err := c.NewBatch().
+		Concurrency(1).
+		AddRecord(schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.1"}).
+		AddForward(schema.ForwardParams{Domain: "example.com", From: "hello", To: "alice@example.net"}).
+		Send(context.Background())
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := []string{"batch/add-record", "batch/add-forward", "add-record", "add-forward"}
+	if !slices.Equal(seen, want) {
+		t.Errorf("intercepted calls = %q, want %q", seen, want)
+	}
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// redacted replaces secrets in log output.
+const redacted string = "[REDACTED]"
+
+// LogLevels sets the levels at which the client logs its calls.
+//
+// Fields:
+//   - Success: The level for calls that succeeded.
+//   - Retry: The level for failed attempts that are about to be retried.
+//   - Failure: The level for calls that failed.
+type LogLevels struct {
+	Success slog.Level
+	Retry   slog.Level
+	Failure slog.Level
+}
+
+// DefaultLogLevels returns the default LogLevels: successful calls are logged at
+// debug level, retries at warn level and failures at error level.
+func DefaultLogLevels() LogLevels {
+	return LogLevels{
+		Success: slog.LevelDebug,
+		Retry:   slog.LevelWarn,
+		Failure: slog.LevelError,
+	}
+}
+
+// WithLogger sets the logger used to record every call made by the client,
+// including its method, domain, duration, number of attempts and outcome.
+// By default, the client does not log anything.
+//
+// The API key, the Authorization header and attributes with sensitive names
+// such as "token" or "password" are always redacted before they reach the
+// logger's handler.
+//
+// Parameters:
+//   - logger: The logger to write to.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified logger to a Client instance.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels at which calls are logged. See DefaultLogLevels
+// for the levels used if this option is not set.
+//
+// Parameters:
+//   - levels: The levels to use.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified log levels to a Client instance.
+func WithLogLevels(levels LogLevels) ClientOption {
+	return func(client *Client) {
+		client.logLevels = levels
+	}
+}
+
+// buildLogger wraps the configured logger so that secrets are redacted.
+func (c *Client) buildLogger() {
+	if c.logger == nil {
+		return
+	}
+	var secrets []string
+	if c.apiKey != "" {
+		secrets = append(secrets, c.apiKey)
+	}
+	c.logger = slog.New(&redactingHandler{handler: c.logger.Handler(), secrets: secrets})
+}
+
+type attemptsContextKey struct{}
+
+// withAttemptCounter returns a copy of ctx carrying a counter that send
+// increments for every attempt.
+func withAttemptCounter(ctx context.Context) (context.Context, *atomic.Int32) {
+	counter := new(atomic.Int32)
+	return context.WithValue(ctx, attemptsContextKey{}, counter), counter
+}
+
+// countAttempt increments the attempt counter stored in ctx, if any.
+func countAttempt(ctx context.Context) {
+	if counter, ok := ctx.Value(attemptsContextKey{}).(*atomic.Int32); ok {
+		counter.Add(1)
+	}
+}
+
+// logCall records the outcome of a call.
+func (c *Client) logCall(ctx context.Context, method string, params any, start time.Time, attempts int32, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Duration("duration", time.Since(start)),
+		slog.Int("attempts", int(attempts)),
+	}
+	if domain := paramDomain(params); domain != "" {
+		attrs = append(attrs, slog.String("domain", domain))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("outcome", "error"), slog.String("error", err.Error()))
+		c.logger.LogAttrs(ctx, c.logLevels.Failure, "njalla call failed", attrs...)
+		return
+	}
+	attrs = append(attrs, slog.String("outcome", "ok"))
+	c.logger.LogAttrs(ctx, c.logLevels.Success, "njalla call succeeded", attrs...)
+}
+
+// logRetry records a failed attempt that is about to be retried.
+func (c *Client) logRetry(ctx context.Context, method string, attempt int, wait time.Duration, status int, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.Int("attempt", attempt),
+		slog.Duration("backoff", wait),
+	}
+	if status != 0 {
+		attrs = append(attrs, slog.Int("status", status))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.logger.LogAttrs(ctx, c.logLevels.Retry, "njalla call retrying", attrs...)
+}
+
+// paramDomain returns the "domain" field of the given params, if any.
+func paramDomain(params any) string {
+	if params == nil {
+		return ""
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+	var fields struct {
+		Domain string `json:"domain"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return ""
+	}
+	return fields.Domain
+}
+
+// sensitiveKeys lists attribute keys whose values are always redacted.
+var sensitiveKeys = []string{"authorization", "api_key", "apikey", "token", "password", "secret", "private_key", "privatekey", "preshared_key"}
+
+// isSensitiveKey reports whether an attribute key names a secret.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingHandler is a slog.Handler that removes secrets from records before
+// passing them on.
+type redactingHandler struct {
+	handler slog.Handler
+	secrets []string
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	secrets := h.secrets
+	if apiKey, ok := contextAPIKey(ctx); ok && apiKey != "" {
+		secrets = append(secrets[:len(secrets):len(secrets)], apiKey)
+	}
+	record := slog.NewRecord(r.Time, r.Level, redactString(r.Message, secrets), r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		record.AddAttrs(redactAttr(attr, secrets))
+		return true
+	})
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redactedAttrs[i] = redactAttr(attr, h.secrets)
+	}
+	return &redactingHandler{handler: h.handler.WithAttrs(redactedAttrs), secrets: h.secrets}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{handler: h.handler.WithGroup(name), secrets: h.secrets}
+}
+
+// redactAttr redacts an attribute with a sensitive key, or any secret contained
+// in its value.
+func redactAttr(attr slog.Attr, secrets []string) slog.Attr {
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redactedGroup := make([]slog.Attr, len(group))
+		for i, member := range group {
+			redactedGroup[i] = redactAttr(member, secrets)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(redactedGroup...)}
+	case slog.KindString:
+		return slog.String(attr.Key, redactString(value.String(), secrets))
+	case slog.KindAny:
+		return slog.String(attr.Key, redactString(value.String(), secrets))
+	default:
+		return slog.Attr{Key: attr.Key, Value: value}
+	}
+}
+
+// redactString replaces every occurrence of the given secrets in s.
+func redactString(s string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
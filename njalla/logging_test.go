@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	apiKey := strings.Repeat("k", 40)
+	contextKey := strings.Repeat("c", 40)
+	// The server echoes the Authorization header in its error message, so the
+	// logged error contains the key the call was made with.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"error":{"code":500,"message":"rejected %s"}}`, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := client.NewClient(client.WithEndpoint(srv.URL), client.APIKey(apiKey), client.WithLogger(logger))
+	ctx := context.Background()
+	keyCtx := client.ContextWithAPIKey(ctx, contextKey)
+
+	if _, err := c.Domain.ListDomains(ctx); err == nil {
+		t.Fatal("ListDomains() error = nil, want the server error")
+	}
+	if _, err := c.Domain.ListDomains(keyCtx); err == nil {
+		t.Fatal("ListDomains() with a context key error = nil, want the server error")
+	}
+
+	wrapped := client.Logger(c)
+	wrapped.With(
+		slog.String("token", "with-attrs-token"),
+		slog.Group("vpn", slog.String("preshared_key", "with-attrs-psk"), slog.String("note", "key "+apiKey)),
+	).WithGroup("call").InfoContext(keyCtx, "message with "+apiKey,
+		slog.Group("credentials", slog.String("token", "group-token"), slog.String("note", "key "+contextKey)),
+		slog.String("preshared_key", "top-level-psk"),
+		slog.Any("error", fmt.Errorf("wrapped %s", apiKey)),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{apiKey, contextKey, "with-attrs-token", "with-attrs-psk", "group-token", "top-level-psk"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains the secret %q:\n%s", secret, out)
+		}
+	}
+	if got := strings.Count(out, `"outcome":"error"`); got != 2 {
+		t.Errorf("logged failed calls = %d, want 2:\n%s", got, out)
+	}
+	for _, want := range []string{`"token":"[REDACTED]"`, `"preshared_key":"[REDACTED]"`, `"vpn":{`, `"credentials":{`, `"note":"key [REDACTED]"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output lacks %s:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// NameserverError is returned by SetNameservers when the nameservers fail
+// client-side validation. It lists every invalid entry and works with
+// errors.As for each FieldError.
+type NameserverError struct {
+	Domain string
+	Errors []*FieldError
+}
+
+// Error implements the error interface.
+func (e *NameserverError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid nameservers for %s: %s", e.Domain, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual field errors.
+func (e *NameserverError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// GetNameservers retrieves the nameservers a domain is delegated to.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//
+// Returns:
+//   - A slice with the host names of the nameservers. It is empty if the
+//     domain uses the Njalla nameservers.
+//   - An error if the request fails.
+func (c *DomainClient) GetNameservers(ctx context.Context, domain string) ([]string, error) {
+	d, err := c.GetDomain(ctx, schema.GetDomainParams{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+	return d.Nameservers, nil
+}
+
+// SetNameservers delegates a domain to custom nameservers.
+// It sends a request to the "edit-domain" API endpoint with only the
+// nameservers, so the other settings of the domain are left alone.
+//
+// Before sending, it checks that the nameservers are valid, distinct host
+// names, that there are no more of them than the domain's MaxNameservers, and
+// that every nameserver inside the domain itself (e.g. "ns1.example.com" for
+// "example.com") has a glue record with an address, since resolvers could not
+// find it otherwise.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//   - nameservers: The host names of the nameservers. An empty list switches
+//     the domain back to the Njalla nameservers, as ResetNameservers does.
+//
+// Returns:
+//   - A pointer to schema.UpdateDomainRequestResponse containing the updated domain.
+//   - A *NameserverError listing every invalid nameserver, or an error if a request fails.
+func (c *DomainClient) SetNameservers(ctx context.Context, domain string, nameservers []string) (*schema.UpdateDomainRequestResponse, error) {
+	const method string = "edit-domain"
+	var responseScheme schema.UpdateDomainRequestResponse
+
+	d, err := c.GetDomain(ctx, schema.GetDomainParams{Domain: domain})
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		normalized = append(normalized, strings.ToLower(strings.TrimSuffix(strings.TrimSpace(ns), ".")))
+	}
+	if err := c.validateNameservers(ctx, d, normalized); err != nil {
+		return nil, err
+	}
+
+	params := schema.SetNameserversParams{
+		Domain:      domain,
+		Nameservers: normalized,
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.UpdateDomainRequestResponse)
+	return response, nil
+}
+
+// ResetNameservers switches a domain back to the Njalla nameservers.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//
+// Returns:
+//   - A pointer to schema.UpdateDomainRequestResponse containing the updated domain.
+//   - An error if the request fails.
+func (c *DomainClient) ResetNameservers(ctx context.Context, domain string) (*schema.UpdateDomainRequestResponse, error) {
+	return c.SetNameservers(ctx, domain, nil)
+}
+
+// validateNameservers checks a list of normalized nameservers for a domain,
+// looking up its glue records if any nameserver is inside the domain.
+func (c *DomainClient) validateNameservers(ctx context.Context, d *schema.GetDomainRequestResponse, nameservers []string) error {
+	v := &recordValidator{}
+	if d.MaxNameservers > 0 && len(nameservers) > d.MaxNameservers {
+		v.fail("nameservers", len(nameservers), "must be at most %d", d.MaxNameservers)
+	}
+
+	domain := strings.ToLower(strings.TrimSuffix(d.Name, "."))
+	seen := make(map[string]bool, len(nameservers))
+	var inBailiwick []int
+	for i, ns := range nameservers {
+		field := fmt.Sprintf("nameservers[%d]", i)
+		if reason := hostnameError(ns, false); reason != "" {
+			v.fail(field, ns, "%s", reason)
+			continue
+		}
+		if seen[ns] {
+			v.fail(field, ns, "is listed more than once")
+		}
+		seen[ns] = true
+		if ns == domain || strings.HasSuffix(ns, "."+domain) {
+			inBailiwick = append(inBailiwick, i)
+		}
+	}
+
+	if len(inBailiwick) > 0 {
+		glue, err := c.client.Glue.ListGlue(ctx, d.Name)
+		if err != nil {
+			return err
+		}
+		for _, i := range inBailiwick {
+			if !hasGlue(glue, nameservers[i], domain) {
+				v.fail(fmt.Sprintf("nameservers[%d]", i), nameservers[i], "is inside %s but has no glue record with an address", domain)
+			}
+		}
+	}
+
+	if len(v.errs) > 0 {
+		return &NameserverError{Domain: d.Name, Errors: v.errs}
+	}
+	return nil
+}
+
+// hasGlue reports whether glue contains an address for the nameserver ns inside
+// domain. Glue records may be named relative to the domain or fully qualified.
+func hasGlue(glue []schema.GlueResponse, ns, domain string) bool {
+	label := strings.TrimSuffix(strings.TrimSuffix(ns, domain), ".")
+	for _, g := range glue {
+		name := strings.ToLower(strings.TrimSuffix(g.Name, "."))
+		if (name == ns || name == label) && (g.Address4 != "" || g.Address6 != "") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestSetNameserversError(t *testing.T) {
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com"}}})
+
+	_, err := srv.Client().Domain.SetNameservers(context.Background(), "example.com", []string{"bad_label-."})
+	var nsErr *client.NameserverError
+	if !errors.As(err, &nsErr) || nsErr.Domain != "example.com" {
+		t.Fatalf("SetNameservers() error = %v, want a *NameserverError for example.com", err)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "invalid nameservers for example.com: nameservers[0]") {
+		t.Errorf("error message = %q", msg)
+	}
+	var fieldErr *client.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "nameservers[0]" {
+		t.Errorf("errors.As(FieldError) = %v, want the field error of the first nameserver", fieldErr)
+	}
+}
+
+func TestSetNameservers(t *testing.T) {
+	original := []string{"ns0.example.net"}
+	tests := []struct {
+		name        string
+		nameservers []string
+		want        []string
+		wantFields  []string
+		wantGlue    bool
+	}{
+		{
+			name:        "external nameservers are normalized",
+			nameservers: []string{" NS1.Example.NET. ", "ns2.example.net"},
+			want:        []string{"ns1.example.net", "ns2.example.net"},
+		},
+		{
+			name:        "duplicate",
+			nameservers: []string{"ns1.example.net", "NS1.example.net."},
+			wantFields:  []string{"nameservers[1]"},
+		},
+		{
+			name:        "more than the domain allows",
+			nameservers: []string{"ns1.example.net", "ns2.example.net", "ns3.example.net", "ns4.example.net"},
+			wantFields:  []string{"nameservers"},
+		},
+		{
+			name:        "invalid host names",
+			nameservers: []string{"", "bad_label-.example.net"},
+			wantFields:  []string{"nameservers[0]", "nameservers[1]"},
+		},
+		{
+			name:        "inside the domain with glue",
+			nameservers: []string{"ns1.example.com", "ns.example.net"},
+			want:        []string{"ns1.example.com", "ns.example.net"},
+			wantGlue:    true,
+		},
+		{
+			name:        "inside the domain with fully qualified glue",
+			nameservers: []string{"ns3.example.com"},
+			want:        []string{"ns3.example.com"},
+			wantGlue:    true,
+		},
+		{
+			name:        "inside the domain without glue",
+			nameservers: []string{"ns.example.net", "ns9.example.com"},
+			wantFields:  []string{"nameservers[1]"},
+			wantGlue:    true,
+		},
+		{
+			name:        "inside the domain with glue lacking an address",
+			nameservers: []string{"ns2.example.com"},
+			wantFields:  []string{"nameservers[0]"},
+			wantGlue:    true,
+		},
+		{
+			name:        "the apex itself",
+			nameservers: []string{"example.com"},
+			wantFields:  []string{"nameservers[0]"},
+			wantGlue:    true,
+		},
+		{
+			name:        "a domain that only ends like the domain",
+			nameservers: []string{"ns1.notexample.com"},
+			want:        []string{"ns1.notexample.com"},
+		},
+		{
+			name: "empty list resets",
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := njallatest.NewServer()
+			defer srv.Close()
+			srv.Seed(njallatest.Fixture{
+				Domains: []schema.GetDomainRequestResponse{{Name: "example.com", MaxNameservers: 3, Nameservers: original}},
+				Glue: map[string][]schema.GlueResponse{"example.com": {
+					{Name: "ns1", Address4: "192.0.2.53"},
+					{Name: "ns2"},
+					{Name: "ns3.example.com.", Address6: "2001:db8::53"},
+				}},
+			})
+			c := srv.Client()
+
+			_, err := c.Domain.SetNameservers(context.Background(), "example.com", tt.nameservers)
+			if tt.wantFields != nil {
+				var nsErr *client.NameserverError
+				if !errors.As(err, &nsErr) {
+					t.Fatalf("SetNameservers() error = %v, want a *NameserverError", err)
+				}
+				var fields []string
+				for _, fieldErr := range nsErr.Errors {
+					fields = append(fields, fieldErr.Field)
+				}
+				if !slices.Equal(fields, tt.wantFields) {
+					t.Errorf("invalid fields = %v, want %v (%v)", fields, tt.wantFields, err)
+				}
+				if got := len(srv.RequestsFor("edit-domain")); got != 0 {
+					t.Errorf("edit-domain requests = %d, want 0", got)
+				}
+			} else if err != nil {
+				t.Fatalf("SetNameservers() error = %v", err)
+			}
+
+			want := tt.want
+			if tt.wantFields != nil {
+				want = original
+			}
+			got, err := c.Domain.GetNameservers(context.Background(), "example.com")
+			if err != nil {
+				t.Fatalf("GetNameservers() error = %v", err)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("nameservers = %q, want %q", got, want)
+			}
+			if looked := len(srv.RequestsFor("list-glue")) > 0; looked != tt.wantGlue {
+				t.Errorf("looked up glue = %t, want %t", looked, tt.wantGlue)
+			}
+		})
+	}
+}
+
+func TestResetNameservers(t *testing.T) {
+	srv := njallatest.NewServer()
+	defer srv.Close()
+	srv.Seed(njallatest.Fixture{Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Nameservers: []string{"ns1.example.net"}}}})
+	c := srv.Client()
+
+	if _, err := c.Domain.ResetNameservers(context.Background(), "example.com"); err != nil {
+		t.Fatalf("ResetNameservers() error = %v", err)
+	}
+	requests := srv.RequestsFor("edit-domain")
+	if len(requests) != 1 || string(requests[0].Params) != `{"domain":"example.com","nameservers":[]}` {
+		t.Fatalf("edit-domain requests = %+v, want one with an empty list", requests)
+	}
+	if d, _ := srv.Domain("example.com"); len(d.Nameservers) != 0 {
+		t.Errorf("nameservers = %q, want none", d.Nameservers)
+	}
+	if _, err := c.Domain.ResetNameservers(context.Background(), "example.org"); !client.IsNotFound(err) {
+		t.Errorf("ResetNameservers() of an unknown domain error = %v, want a not found error", err)
+	}
+}
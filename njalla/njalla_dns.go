@@ -1,6 +1,9 @@
 package client
 
+import "time"
+
 const APIVersion string = "1.0"
 const Endpoint string = "https://njal.la/api/1/"
 const HTTPMethod string = "POST"
 const UserAgent string = "njalla-dns/" + APIVersion
+const DefaultTimeout time.Duration = 30 * time.Second
@@ -0,0 +1,434 @@
+package njallatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// defaultHandlers returns the handlers for every method the fake implements.
+func defaultHandlers() map[string]handlerFunc {
+	return map[string]handlerFunc{
+		"list-domains":   (*Server).listDomains,
+		"get-domain":     (*Server).getDomain,
+		"edit-domain":    (*Server).editDomain,
+		"list-records":   (*Server).listRecords,
+		"add-record":     (*Server).addRecord,
+		"edit-record":    (*Server).editRecord,
+		"remove-record":  (*Server).removeRecord,
+		"list-forwards":  (*Server).listForwards,
+		"add-forward":    (*Server).addForward,
+		"remove-forward": (*Server).removeForward,
+		"list-glue":      (*Server).listGlue,
+		"add-glue":       (*Server).addGlue,
+		"edit-glue":      (*Server).editGlue,
+		"remove-glue":    (*Server).removeGlue,
+		"list-dnssec":    (*Server).listDNSSEC,
+		"add-dnssec":     (*Server).addDNSSEC,
+		"remove-dnssec":  (*Server).removeDNSSEC,
+		"list-servers":   (*Server).listServers,
+		"get-server":     (*Server).getServer,
+		"start-server":   serverStatus("running"),
+		"stop-server":    serverStatus("stopped"),
+		"restart-server": serverStatus("running"),
+		"reset-server":   (*Server).resetServer,
+		"renew-server":   (*Server).renewServer,
+		"remove-server":  (*Server).removeServer,
+	}
+}
+
+// decodeParams unmarshals the params of a request into v.
+func decodeParams(params json.RawMessage, v any) *client.APIError {
+	if len(params) == 0 {
+		params = json.RawMessage("{}")
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &client.APIError{Code: CodeInvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
+
+// domainParams is the params shape shared by every per-domain method.
+type domainParams struct {
+	Domain string `json:"domain"`
+}
+
+// decodeDomain unmarshals the "domain" param and checks that the domain exists.
+func (s *Server) decodeDomain(params json.RawMessage) (string, *client.APIError) {
+	var p domainParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return "", apiErr
+	}
+	return p.Domain, s.requireDomain(p.Domain)
+}
+
+func notFound(format string, args ...any) *client.APIError {
+	return &client.APIError{Code: CodeNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func alreadyExists(format string, args ...any) *client.APIError {
+	return &client.APIError{Code: CodeAlreadyExists, Message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) listDomains(json.RawMessage) (any, *client.APIError) {
+	domains := make([]schema.ListDomainResponse, 0, len(s.domains))
+	for _, d := range s.domains {
+		domains = append(domains, schema.ListDomainResponse{
+			Name:      d.Name,
+			Status:    d.Status,
+			Expiry:    d.Expiry,
+			Autorenew: d.Autorenew,
+		})
+	}
+	return schema.ListDomainsRequestResponse{Domains: domains}, nil
+}
+
+func (s *Server) getDomain(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return s.domains[s.domainIndex(domain)], nil
+}
+
+func (s *Server) editDomain(params json.RawMessage) (any, *client.APIError) {
+	var p struct {
+		Domain         string    `json:"domain"`
+		MailForwarding *bool     `json:"mailforwarding"`
+		DNSSEC         *bool     `json:"dnssec"`
+		Lock           *bool     `json:"lock"`
+		Autorenew      *bool     `json:"autorenew"`
+		Nameservers    *[]string `json:"nameservers"`
+	}
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	d := &s.domains[s.domainIndex(p.Domain)]
+	if p.MailForwarding != nil {
+		d.Mailforwarding = *p.MailForwarding
+	}
+	if p.Lock != nil {
+		d.Locked = *p.Lock
+	}
+	if p.Autorenew != nil {
+		d.Autorenew = *p.Autorenew
+	}
+	if p.Nameservers != nil {
+		d.Nameservers = slices.Clone(*p.Nameservers)
+	}
+	if p.DNSSEC != nil {
+		d.DNSSECType = ""
+		if *p.DNSSEC {
+			d.DNSSECType = "ds"
+		}
+	}
+	return schema.UpdateDomainRequestResponse{
+		Name:           d.Name,
+		Status:         d.Status,
+		Expiry:         d.Expiry,
+		Autorenew:      d.Autorenew,
+		Locked:         d.Locked,
+		Mailforwarding: d.Mailforwarding,
+		MaxNameservers: d.MaxNameservers,
+		Nameservers:    d.Nameservers,
+		DNSSECType:     d.DNSSECType,
+		MaxStaticPages: d.MaxStaticPages,
+	}, nil
+}
+
+func (s *Server) listRecords(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.RecordsListRequestResponse{Records: slices.Clone(s.records[domain])}, nil
+}
+
+func (s *Server) addRecord(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	var record schema.RecordResponse
+	if apiErr := decodeParams(params, &record); apiErr != nil {
+		return nil, apiErr
+	}
+	delete(record.Extra, "domain")
+	record.ID = s.newID()
+	s.records[domain] = append(s.records[domain], record)
+	return record, nil
+}
+
+func (s *Server) editRecord(params json.RawMessage) (any, *client.APIError) {
+	var p struct {
+		Domain string `json:"domain"`
+		ID     string `json:"id"`
+	}
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := slices.IndexFunc(s.records[p.Domain], func(r schema.RecordResponse) bool { return r.ID == p.ID })
+	if i < 0 {
+		return nil, notFound("record %s not found", p.ID)
+	}
+	// Unmarshaling onto the existing record only overwrites the fields present in params.
+	record := s.records[p.Domain][i]
+	if apiErr := decodeParams(params, &record); apiErr != nil {
+		return nil, apiErr
+	}
+	delete(record.Extra, "domain")
+	s.records[p.Domain][i] = record
+	return record, nil
+}
+
+func (s *Server) removeRecord(params json.RawMessage) (any, *client.APIError) {
+	var p schema.RecordDeleteParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := slices.IndexFunc(s.records[p.Domain], func(r schema.RecordResponse) bool { return r.ID == p.ID })
+	if i < 0 {
+		return nil, notFound("record %s not found", p.ID)
+	}
+	s.records[p.Domain] = slices.Delete(s.records[p.Domain], i, i+1)
+	return struct{}{}, nil
+}
+
+func (s *Server) listForwards(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.ForwardListRequestResponse{Forward: slices.Clone(s.forwards[domain])}, nil
+}
+
+func (s *Server) addForward(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ForwardParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	forward := schema.ForwardResponse{From: p.From, To: p.To}
+	if slices.Contains(s.forwards[p.Domain], forward) {
+		return nil, alreadyExists("forward from %s to %s already exists", p.From, p.To)
+	}
+	s.forwards[p.Domain] = append(s.forwards[p.Domain], forward)
+	return schema.ForwardCreateRequestResponse(p), nil
+}
+
+func (s *Server) removeForward(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ForwardParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := slices.Index(s.forwards[p.Domain], schema.ForwardResponse{From: p.From, To: p.To})
+	if i < 0 {
+		return nil, notFound("forward from %s to %s not found", p.From, p.To)
+	}
+	s.forwards[p.Domain] = slices.Delete(s.forwards[p.Domain], i, i+1)
+	return struct{}{}, nil
+}
+
+func (s *Server) listGlue(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.GlueListRequestResponse{Glue: slices.Clone(s.glue[domain])}, nil
+}
+
+// glueIndex returns the index of a glue record, or -1 if it does not exist.
+func (s *Server) glueIndex(domain, name string) int {
+	return slices.IndexFunc(s.glue[domain], func(g schema.GlueResponse) bool { return g.Name == name })
+}
+
+func (s *Server) addGlue(params json.RawMessage) (any, *client.APIError) {
+	var p schema.GlueParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	if s.glueIndex(p.Domain, p.Name) >= 0 {
+		return nil, alreadyExists("glue record %s already exists", p.Name)
+	}
+	s.glue[p.Domain] = append(s.glue[p.Domain], schema.GlueResponse{Name: p.Name, Address4: p.Address4, Address6: p.Address6})
+	return struct{}{}, nil
+}
+
+func (s *Server) editGlue(params json.RawMessage) (any, *client.APIError) {
+	var p schema.GlueParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := s.glueIndex(p.Domain, p.Name)
+	if i < 0 {
+		return nil, notFound("glue record %s not found", p.Name)
+	}
+	s.glue[p.Domain][i] = schema.GlueResponse{Name: p.Name, Address4: p.Address4, Address6: p.Address6}
+	return struct{}{}, nil
+}
+
+func (s *Server) removeGlue(params json.RawMessage) (any, *client.APIError) {
+	var p schema.GlueDeleteParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := s.glueIndex(p.Domain, p.Name)
+	if i < 0 {
+		return nil, notFound("glue record %s not found", p.Name)
+	}
+	s.glue[p.Domain] = slices.Delete(s.glue[p.Domain], i, i+1)
+	return struct{}{}, nil
+}
+
+func (s *Server) listDNSSEC(params json.RawMessage) (any, *client.APIError) {
+	domain, apiErr := s.decodeDomain(params)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.DNSSECListRequestResponse{DNSSec: slices.Clone(s.dnssec[domain])}, nil
+}
+
+func (s *Server) addDNSSEC(params json.RawMessage) (any, *client.APIError) {
+	var p schema.DNSSECCreateParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	record := schema.DNSSECResponse{
+		ID:         s.newID(),
+		Algorithm:  p.Algorithm,
+		Digest:     p.Digest,
+		DigestType: p.DigestType,
+		KeyTag:     p.KeyTag,
+		PublicKey:  p.PublicKey,
+	}
+	s.dnssec[p.Domain] = append(s.dnssec[p.Domain], record)
+	return schema.DNSSECCreateRequestResponse(record), nil
+}
+
+func (s *Server) removeDNSSEC(params json.RawMessage) (any, *client.APIError) {
+	var p schema.DNSSECDeleteParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if apiErr := s.requireDomain(p.Domain); apiErr != nil {
+		return nil, apiErr
+	}
+	i := slices.IndexFunc(s.dnssec[p.Domain], func(r schema.DNSSECResponse) bool { return r.ID == p.ID })
+	if i < 0 {
+		return nil, notFound("DNSSEC record %s not found", p.ID)
+	}
+	s.dnssec[p.Domain] = slices.Delete(s.dnssec[p.Domain], i, i+1)
+	return struct{}{}, nil
+}
+
+// serverIndex returns the index of a server, or an error if it does not exist.
+// It must be called with the lock held.
+func (s *Server) serverIndex(id string) (int, *client.APIError) {
+	i := slices.IndexFunc(s.servers, func(server schema.ServerResponse) bool { return server.ID == id })
+	if i < 0 {
+		return -1, notFound("server %s not found", id)
+	}
+	return i, nil
+}
+
+func (s *Server) listServers(json.RawMessage) (any, *client.APIError) {
+	return schema.ServerListRequestResponse{Servers: slices.Clone(s.servers)}, nil
+}
+
+func (s *Server) getServer(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ServerParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	i, apiErr := s.serverIndex(p.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.ServerGetRequestResponse(s.servers[i]), nil
+}
+
+// serverStatus returns a handler that sets the status of a server.
+func serverStatus(status string) handlerFunc {
+	return func(s *Server, params json.RawMessage) (any, *client.APIError) {
+		var p schema.ServerParams
+		if apiErr := decodeParams(params, &p); apiErr != nil {
+			return nil, apiErr
+		}
+		i, apiErr := s.serverIndex(p.ID)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		s.servers[i].Status = status
+		return schema.ServerActionRequestResponse{}, nil
+	}
+}
+
+func (s *Server) resetServer(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ServerResetParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	i, apiErr := s.serverIndex(p.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	server := &s.servers[i]
+	server.OS, server.SSHKey, server.Status = p.OS, p.SSHKey, "running"
+	if p.Type != "" {
+		server.Type = p.Type
+	}
+	return schema.ServerActionRequestResponse{}, nil
+}
+
+func (s *Server) renewServer(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ServerRenewParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	if _, apiErr := s.serverIndex(p.ID); apiErr != nil {
+		return nil, apiErr
+	}
+	return schema.ServerActionRequestResponse{}, nil
+}
+
+func (s *Server) removeServer(params json.RawMessage) (any, *client.APIError) {
+	var p schema.ServerParams
+	if apiErr := decodeParams(params, &p); apiErr != nil {
+		return nil, apiErr
+	}
+	i, apiErr := s.serverIndex(p.ID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	s.servers = slices.Delete(s.servers, i, i+1)
+	return struct{}{}, nil
+}
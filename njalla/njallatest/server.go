@@ -0,0 +1,379 @@
+// Package njallatest provides an in-memory fake of the Njalla JSON-RPC API for
+// testing code that uses the client package without network access.
+//
+// The fake implements the domain, record, forward, glue, DNSSEC and server
+// methods against in-memory state. State can be seeded with fixtures, errors can be
+// injected per method, and every request received is recorded for inspection.
+//
+// Example:
+//
+//	srv := njallatest.NewServer()
+//	defer srv.Close()
+//	srv.Seed(njallatest.Fixture{
+//	    Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+//	})
+//	c := srv.Client()
+//	records, err := c.Record.ListRecords(ctx, "example.com")
+package njallatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Error codes returned by the fake server.
+const (
+	CodeNotFound       int = 404
+	CodeAlreadyExists  int = 409
+	CodeInvalidRequest int = -32600
+	CodeInvalidParams  int = -32602
+	CodeMethodNotFound int = -32601
+	CodeUnauthorized   int = 401
+)
+
+// Fixture describes the initial state of a Server.
+//
+// Fields:
+//   - Domains: The domains owned by the account.
+//   - Records: The DNS records of each domain, keyed by domain name.
+//   - Forwards: The mail forwards of each domain, keyed by domain name.
+//   - Glue: The glue records of each domain, keyed by domain name.
+//   - DNSSEC: The DNSSEC records of each domain, keyed by domain name.
+//   - Servers: The VPS servers of the account.
+//
+// Records, DNSSEC records and servers without an ID are assigned one.
+type Fixture struct {
+	Domains  []schema.GetDomainRequestResponse
+	Records  map[string][]schema.RecordResponse
+	Forwards map[string][]schema.ForwardResponse
+	Glue     map[string][]schema.GlueResponse
+	DNSSEC   map[string][]schema.DNSSECResponse
+	Servers  []schema.ServerResponse
+}
+
+// Request is a JSON-RPC request received by a Server.
+type Request struct {
+	Method string
+	Params json.RawMessage
+	Header http.Header
+}
+
+// injectedError is an error registered with InjectError or FailNext.
+type injectedError struct {
+	err  client.APIError
+	once bool
+}
+
+// handlerFunc handles the params of a JSON-RPC method. It is called with the
+// server's lock held.
+type handlerFunc func(s *Server, params json.RawMessage) (any, *client.APIError)
+
+// Server is an in-memory fake of the Njalla API, served over HTTP by an
+// httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	apiKey   string
+	nextID   int
+	domains  []schema.GetDomainRequestResponse
+	records  map[string][]schema.RecordResponse
+	forwards map[string][]schema.ForwardResponse
+	glue     map[string][]schema.GlueResponse
+	dnssec   map[string][]schema.DNSSECResponse
+	servers  []schema.ServerResponse
+	errors   map[string][]injectedError
+	requests []Request
+	handlers map[string]handlerFunc
+}
+
+// NewServer starts and returns a new Server with empty state.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		records:  map[string][]schema.RecordResponse{},
+		forwards: map[string][]schema.ForwardResponse{},
+		glue:     map[string][]schema.GlueResponse{},
+		dnssec:   map[string][]schema.DNSSECResponse{},
+		errors:   map[string][]injectedError{},
+		handlers: defaultHandlers(),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client.Client that sends its requests to the server.
+// Additional options are applied after the endpoint is set.
+func (s *Server) Client(options ...client.ClientOption) *client.Client {
+	options = append([]client.ClientOption{client.WithEndpoint(s.URL)}, options...)
+	return client.NewClient(options...)
+}
+
+// RequireAPIKey makes the server reject requests that do not carry the given
+// API key in their Authorization header.
+func (s *Server) RequireAPIKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKey = apiKey
+}
+
+// Seed adds the state described by fixture to the server.
+func (s *Server) Seed(fixture Fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.domains = append(s.domains, fixture.Domains...)
+	for domain, records := range fixture.Records {
+		for _, record := range records {
+			if record.ID == "" {
+				record.ID = s.newID()
+			} else {
+				s.reserveID(record.ID)
+			}
+			s.records[domain] = append(s.records[domain], record)
+		}
+	}
+	for domain, forwards := range fixture.Forwards {
+		s.forwards[domain] = append(s.forwards[domain], forwards...)
+	}
+	for domain, glue := range fixture.Glue {
+		s.glue[domain] = append(s.glue[domain], glue...)
+	}
+	for domain, records := range fixture.DNSSEC {
+		for _, record := range records {
+			if record.ID == "" {
+				record.ID = s.newID()
+			} else {
+				s.reserveID(record.ID)
+			}
+			s.dnssec[domain] = append(s.dnssec[domain], record)
+		}
+	}
+	for _, server := range fixture.Servers {
+		if server.ID == "" {
+			server.ID = s.newID()
+		} else {
+			s.reserveID(server.ID)
+		}
+		s.servers = append(s.servers, server)
+	}
+}
+
+// InjectError makes every following call of method fail with the given error,
+// until ClearErrors is called. A zero StatusCode is sent as 200, like the
+// errors returned by the real API.
+func (s *Server) InjectError(method string, err client.APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[method] = append(s.errors[method], injectedError{err: err})
+}
+
+// FailNext makes the next call of method fail with the given error. Calling it
+// several times queues several failures.
+func (s *Server) FailNext(method string, err client.APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[method] = append(s.errors[method], injectedError{err: err, once: true})
+}
+
+// ClearErrors removes all injected errors.
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = map[string][]injectedError{}
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// RequestsFor returns the requests received so far for the given method, in order.
+func (s *Server) RequestsFor(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, req := range s.requests {
+		if req.Method == method {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// Domain returns the current state of a domain.
+func (s *Server) Domain(name string) (schema.GetDomainRequestResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.domainIndex(name); i >= 0 {
+		return s.domains[i], true
+	}
+	return schema.GetDomainRequestResponse{}, false
+}
+
+// Records returns the current DNS records of a domain.
+func (s *Server) Records(domain string) []schema.RecordResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.records[domain])
+}
+
+// Forwards returns the current mail forwards of a domain.
+func (s *Server) Forwards(domain string) []schema.ForwardResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.forwards[domain])
+}
+
+// Glue returns the current glue records of a domain.
+func (s *Server) Glue(domain string) []schema.GlueResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.glue[domain])
+}
+
+// DNSSEC returns the current DNSSEC records of a domain.
+func (s *Server) DNSSEC(domain string) []schema.DNSSECResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.dnssec[domain])
+}
+
+// Servers returns the current VPS servers of the account.
+func (s *Server) Servers() []schema.ServerResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.servers)
+}
+
+// newID returns a new unique identifier. It must be called with the lock held.
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// reserveID makes sure newID never returns a numeric ID seeded by a fixture.
+// It must be called with the lock held.
+func (s *Server) reserveID(id string) {
+	if n, err := strconv.Atoi(id); err == nil {
+		s.nextID = max(s.nextID, n)
+	}
+}
+
+// domainIndex returns the index of a domain, or -1 if it does not exist.
+// It must be called with the lock held.
+func (s *Server) domainIndex(name string) int {
+	return slices.IndexFunc(s.domains, func(d schema.GetDomainRequestResponse) bool {
+		return d.Name == name
+	})
+}
+
+// requireDomain returns an error if the domain does not exist.
+// It must be called with the lock held.
+func (s *Server) requireDomain(name string) *client.APIError {
+	if s.domainIndex(name) < 0 {
+		return &client.APIError{Code: CodeNotFound, Message: fmt.Sprintf("domain %s not found", name)}
+	}
+	return nil
+}
+
+// takeError returns the injected error for a method, if any.
+// It must be called with the lock held.
+func (s *Server) takeError(method string) *client.APIError {
+	queue := s.errors[method]
+	if len(queue) == 0 {
+		return nil
+	}
+	injected := queue[0]
+	if injected.once {
+		s.errors[method] = queue[1:]
+	}
+	return &injected.err
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var envelope struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, &client.APIError{Code: CodeInvalidRequest, Message: "invalid request body", StatusCode: http.StatusBadRequest})
+		return
+	}
+	// The fake does not implement batch requests, so batched calls make the
+	// client fall back to single calls.
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		writeError(w, &client.APIError{Code: CodeInvalidRequest, Message: "batch requests are not supported", StatusCode: http.StatusBadRequest})
+		return
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		writeError(w, &client.APIError{Code: CodeInvalidParams, Message: "invalid request body", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, Request{
+		Method: envelope.Method,
+		Params: envelope.Params,
+		Header: r.Header.Clone(),
+	})
+
+	if s.apiKey != "" && r.Header.Get("Authorization") != "Njalla "+s.apiKey {
+		writeError(w, &client.APIError{Code: CodeUnauthorized, Message: "invalid API key"})
+		return
+	}
+	if apiErr := s.takeError(envelope.Method); apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	handler, ok := s.handlers[envelope.Method]
+	if !ok {
+		writeError(w, &client.APIError{Code: CodeMethodNotFound, Message: fmt.Sprintf("method %s not found", envelope.Method)})
+		return
+	}
+	result, apiErr := handler(s, envelope.Params)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	writeResult(w, result)
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "result": result})
+}
+
+func writeError(w http.ResponseWriter, apiErr *client.APIError) {
+	w.Header().Set("Content-Type", "application/json")
+	if apiErr.StatusCode != 0 {
+		w.WriteHeader(apiErr.StatusCode)
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"jsonrpc": "2.0",
+		"error": map[string]any{
+			"code":    apiErr.Code,
+			"message": apiErr.Message,
+		},
+	})
+}
@@ -0,0 +1,173 @@
+package njallatest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func newServer(t *testing.T) *njallatest.Server {
+	t.Helper()
+	srv := njallatest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Seed(njallatest.Fixture{
+		Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+		Records: map[string][]schema.RecordResponse{"example.com": {
+			{ID: "7", Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			{Name: "www", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		}},
+	})
+	return srv
+}
+
+func TestSeedKeepsIDsUnique(t *testing.T) {
+	srv := newServer(t)
+	c := srv.Client()
+
+	created, err := c.Record.CreateRecord(context.Background(), schema.RecordCreateParams{
+		Domain: "example.com", Name: "www", Type: "A", Content: "192.0.2.3",
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord() error = %v", err)
+	}
+	seen := map[string]bool{}
+	for _, record := range srv.Records("example.com") {
+		if seen[record.ID] {
+			t.Errorf("record ID %s is used twice", record.ID)
+		}
+		seen[record.ID] = true
+	}
+	if !seen["7"] || !seen[created.ID] || len(seen) != 3 {
+		t.Errorf("record IDs = %v, want 7 and two new ones", seen)
+	}
+}
+
+func TestRecordMethods(t *testing.T) {
+	srv := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+
+	if _, err := c.Record.UpdateRecord(ctx, schema.RecordUpdateParams{ID: "7", Domain: "example.com", Name: "www", Type: "A", Content: "192.0.2.9", TTL: 300}); err != nil {
+		t.Fatalf("UpdateRecord() error = %v", err)
+	}
+	if _, err := c.Record.DeleteRecord(ctx, schema.RecordDeleteParams{ID: "7", Domain: "example.com"}); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+	if _, err := c.Record.DeleteRecord(ctx, schema.RecordDeleteParams{ID: "7", Domain: "example.com"}); !client.IsNotFound(err) {
+		t.Errorf("DeleteRecord() of a removed record error = %v, want a not found error", err)
+	}
+	if _, err := c.Record.ListRecords(ctx, "example.org"); !client.IsNotFound(err) {
+		t.Errorf("ListRecords() of an unknown domain error = %v, want a not found error", err)
+	}
+	records, err := c.Record.ListRecords(ctx, "example.com")
+	if err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+	if len(records) != 1 || records[0].Content != "192.0.2.2" {
+		t.Errorf("ListRecords() = %+v, want the second seeded record", records)
+	}
+	// DeleteRecord checks that the record exists before removing it.
+	if got := len(srv.RequestsFor("remove-record")); got != 1 {
+		t.Errorf("remove-record requests = %d, want 1", got)
+	}
+}
+
+func TestInjectedErrors(t *testing.T) {
+	srv := newServer(t)
+	c := srv.Client()
+	ctx := context.Background()
+	list := func() error {
+		_, err := c.Record.ListRecords(ctx, "example.com")
+		return err
+	}
+
+	srv.FailNext("list-records", client.APIError{Code: 500, Message: "once"})
+	if err := list(); err == nil || !strings.Contains(err.Error(), "once") {
+		t.Errorf("first call error = %v, want the FailNext error", err)
+	}
+	if err := list(); err != nil {
+		t.Errorf("second call error = %v, want nil", err)
+	}
+
+	srv.InjectError("list-records", client.APIError{Code: 429, Message: "slow down"})
+	for i := range 2 {
+		if err := list(); !client.IsRateLimited(err) {
+			t.Errorf("call %d error = %v, want the injected error", i, err)
+		}
+	}
+	srv.ClearErrors()
+	if err := list(); err != nil {
+		t.Errorf("call after ClearErrors() error = %v, want nil", err)
+	}
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	key := strings.Repeat("k", 40)
+	srv := newServer(t)
+	srv.RequireAPIKey(key)
+
+	if _, err := srv.Client().Domain.ListDomains(context.Background()); !client.IsPermissionDenied(err) {
+		t.Errorf("ListDomains() without key error = %v, want a permission error", err)
+	}
+	if _, err := srv.Client(client.APIKey(key)).Domain.ListDomains(context.Background()); err != nil {
+		t.Errorf("ListDomains() with key error = %v, want nil", err)
+	}
+	requests := srv.Requests()
+	if got := requests[len(requests)-1].Header.Get("Authorization"); got != "Njalla "+key {
+		t.Errorf("recorded Authorization header = %q", got)
+	}
+}
+
+func TestProtocolErrors(t *testing.T) {
+	srv := newServer(t)
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   int
+	}{
+		{"unknown method", `{"method":"no-such-method","params":{}}`, http.StatusOK, njallatest.CodeMethodNotFound},
+		{"batch", `[{"method":"list-domains","params":{},"id":0}]`, http.StatusBadRequest, njallatest.CodeInvalidRequest},
+		{"invalid params", `{"method":"list-records","params":[]}`, http.StatusOK, njallatest.CodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(srv.URL, "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var envelope struct {
+				Error *struct {
+					Code int `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus || envelope.Error == nil || envelope.Error.Code != tt.wantCode {
+				t.Errorf("status, error = %d, %+v, want %d with code %d", resp.StatusCode, envelope.Error, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestResetRequests(t *testing.T) {
+	srv := newServer(t)
+	if _, err := srv.Client().Domain.ListDomains(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	srv.ResetRequests()
+	if got := srv.Requests(); len(got) != 0 {
+		t.Errorf("Requests() after ResetRequests() = %d, want 0", len(got))
+	}
+	if _, ok := srv.Domain("example.com"); !ok {
+		t.Error("Domain() lost the seeded domain")
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// DefaultTorAddress is the address of the SOCKS5 port of a locally running Tor daemon.
+const DefaultTorAddress string = "127.0.0.1:9050"
+
+// SOCKS5Auth holds the credentials sent to a SOCKS5 proxy.
+//
+// Tor uses these credentials for stream isolation: requests made with
+// different credentials are sent over different circuits.
+type SOCKS5Auth struct {
+	Username string
+	Password string
+}
+
+// WithSOCKS5Proxy routes every request through the SOCKS5 proxy at the given address.
+// Host names, including onion addresses set with WithEndpoint, are resolved by the
+// proxy, so no DNS queries are made locally.
+//
+// The proxy is applied to a copy of the client's transport, which must be an
+// *http.Transport. If it is not, NewRequest returns an error instead of sending
+// the request without the proxy.
+//
+// Parameters:
+//   - address: The host and port of the SOCKS5 proxy, e.g. "127.0.0.1:9050".
+//   - auth: The credentials for the proxy, or nil if none are required.
+//
+// Returns:
+//
+//	A ClientOption that routes the requests of a Client instance through the proxy.
+func WithSOCKS5Proxy(address string, auth *SOCKS5Auth) ClientOption {
+	return func(client *Client) {
+		proxyURL := &url.URL{Scheme: "socks5h", Host: address}
+		if auth != nil {
+			proxyURL.User = url.UserPassword(auth.Username, auth.Password)
+		}
+		client.proxyURL = proxyURL
+	}
+}
+
+// WithTor routes every request through the Tor SOCKS5 port at the given address.
+// Each client is assigned random proxy credentials, so that Tor isolates its
+// requests on circuits that are not shared with other clients.
+//
+// Parameters:
+//   - address: The host and port of the Tor SOCKS5 port, usually DefaultTorAddress.
+//
+// Returns:
+//
+//	A ClientOption that routes the requests of a Client instance through Tor.
+func WithTor(address string) ClientOption {
+	return func(client *Client) {
+		WithSOCKS5Proxy(address, &SOCKS5Auth{
+			Username: randomToken(),
+			Password: randomToken(),
+		})(client)
+	}
+}
+
+// randomToken returns a random hex string suitable for use as isolation credentials.
+func randomToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// proxyTransport returns a copy of base that sends requests through the
+// configured SOCKS5 proxy.
+func (c *Client) proxyTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, errors.New("SOCKS5 proxy requires an *http.Transport")
+	}
+	transport = transport.Clone()
+	transport.Proxy = http.ProxyURL(c.proxyURL)
+	return transport, nil
+}
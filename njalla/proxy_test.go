@@ -0,0 +1,233 @@
+package client_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+// socksConn is a connection a socksProxy accepted.
+type socksConn struct {
+	username string
+	password string
+	host     string
+}
+
+// socksProxy is a minimal SOCKS5 proxy that accepts CONNECT requests for
+// domain names and connects every one of them to target.
+type socksProxy struct {
+	listener net.Listener
+	target   string
+
+	mu    sync.Mutex
+	conns []socksConn
+}
+
+func newSOCKSProxy(t *testing.T, target string) *socksProxy {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &socksProxy{listener: listener, target: target}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				if err := p.serve(conn); err != nil {
+					t.Errorf("SOCKS5 proxy: %v", err)
+				}
+			}()
+		}
+	}()
+	return p
+}
+
+func (p *socksProxy) addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *socksProxy) accepted() []socksConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]socksConn(nil), p.conns...)
+}
+
+// serve handles a single SOCKS5 connection (RFC 1928 and RFC 1929).
+func (p *socksProxy) serve(conn net.Conn) error {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	readBytes := func(n int) ([]byte, error) {
+		b := make([]byte, n)
+		_, err := io.ReadFull(r, b)
+		return b, err
+	}
+
+	// Greeting: version, number of methods, methods.
+	header, err := readBytes(2)
+	if err != nil {
+		return err
+	}
+	methods, err := readBytes(int(header[1]))
+	if err != nil {
+		return err
+	}
+	var accepted socksConn
+	if strings.ContainsRune(string(methods), 0x02) {
+		conn.Write([]byte{0x05, 0x02})
+		// Username and password: version, ulen, username, plen, password.
+		b, err := readBytes(2)
+		if err != nil {
+			return err
+		}
+		username, err := readBytes(int(b[1]))
+		if err != nil {
+			return err
+		}
+		plen, err := readBytes(1)
+		if err != nil {
+			return err
+		}
+		password, err := readBytes(int(plen[0]))
+		if err != nil {
+			return err
+		}
+		accepted.username, accepted.password = string(username), string(password)
+		conn.Write([]byte{0x01, 0x00})
+	} else {
+		conn.Write([]byte{0x05, 0x00})
+	}
+
+	// Request: version, command, reserved, address type, address, port.
+	request, err := readBytes(4)
+	if err != nil {
+		return err
+	}
+	if request[1] != 0x01 || request[3] != 0x03 {
+		conn.Write([]byte{0x05, 0x08, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+		return fmt.Errorf("got command %d with address type %d, want CONNECT to a domain name", request[1], request[3])
+	}
+	n, err := readBytes(1)
+	if err != nil {
+		return err
+	}
+	host, err := readBytes(int(n[0]))
+	if err != nil {
+		return err
+	}
+	port, err := readBytes(2)
+	if err != nil {
+		return err
+	}
+	accepted.host = net.JoinHostPort(string(host), fmt.Sprint(binary.BigEndian.Uint16(port)))
+	p.mu.Lock()
+	p.conns = append(p.conns, accepted)
+	p.mu.Unlock()
+
+	upstream, err := net.Dial("tcp", p.target)
+	if err != nil {
+		return err
+	}
+	defer upstream.Close()
+	conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 127, 0, 0, 1, 0, 0})
+
+	go io.Copy(upstream, r)
+	io.Copy(conn, upstream)
+	return nil
+}
+
+// newProxiedAPI returns a proxy in front of an API server answering every call
+// with an empty domain list.
+func newProxiedAPI(t *testing.T) *socksProxy {
+	t.Helper()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result":{"domains":[]}}`)
+	}))
+	t.Cleanup(api.Close)
+	return newSOCKSProxy(t, api.Listener.Addr().String())
+}
+
+func TestWithSOCKS5Proxy(t *testing.T) {
+	tests := []struct {
+		name string
+		auth *client.SOCKS5Auth
+	}{
+		{"without credentials", nil},
+		{"with credentials", &client.SOCKS5Auth{Username: "alice", Password: "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := newProxiedAPI(t)
+			// The host name only resolves if the proxy resolves it.
+			c := client.NewClient(
+				client.WithEndpoint("http://njalla.invalid:8080/api/1/"),
+				client.WithSOCKS5Proxy(proxy.addr(), tt.auth),
+			)
+
+			if _, err := c.Domain.ListDomains(context.Background()); err != nil {
+				t.Fatalf("ListDomains() error = %v", err)
+			}
+			conns := proxy.accepted()
+			if len(conns) != 1 {
+				t.Fatalf("proxy connections = %d, want 1", len(conns))
+			}
+			want := socksConn{host: "njalla.invalid:8080"}
+			if tt.auth != nil {
+				want.username, want.password = tt.auth.Username, tt.auth.Password
+			}
+			if conns[0] != want {
+				t.Errorf("proxy connection = %+v, want %+v", conns[0], want)
+			}
+		})
+	}
+}
+
+func TestWithTorIsolatesClients(t *testing.T) {
+	proxy := newProxiedAPI(t)
+	for range 2 {
+		c := client.NewClient(client.WithEndpoint("http://njalla.invalid/api/1/"), client.WithTor(proxy.addr()))
+		if _, err := c.Domain.ListDomains(context.Background()); err != nil {
+			t.Fatalf("ListDomains() error = %v", err)
+		}
+	}
+	conns := proxy.accepted()
+	if len(conns) != 2 {
+		t.Fatalf("proxy connections = %d, want 2", len(conns))
+	}
+	if conns[0].username == "" || conns[0].username == conns[1].username {
+		t.Errorf("proxy usernames = %q and %q, want distinct isolation credentials", conns[0].username, conns[1].username)
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper that is not an *http.Transport.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithSOCKS5ProxyRequiresTransport(t *testing.T) {
+	errSent := errors.New("request sent without the proxy")
+	c := client.NewClient(
+		client.WithTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errSent })),
+		client.WithSOCKS5Proxy("127.0.0.1:1", nil),
+	)
+	if _, err := c.Domain.ListDomains(context.Background()); err == nil || errors.Is(err, errSent) {
+		t.Errorf("ListDomains() error = %v, want a configuration error", err)
+	}
+}
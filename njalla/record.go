@@ -47,17 +47,10 @@ func (c *RecordClient) ListRecords(ctx context.Context, domain string) ([]schema
 	const method string = "list-records"
 	var responseScheme schema.RecordsListRequestResponse
 
-	body := schema.RecordsListRequest{
-		Method: method,
-		Params: schema.RecordListParams{
-			Domain: domain,
-		},
+	params := schema.RecordListParams{
+		Domain: domain,
 	}
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -66,9 +59,11 @@ func (c *RecordClient) ListRecords(ctx context.Context, domain string) ([]schema
 }
 
 // CreateRecord creates a new DNS record for the specified domain.
-// It first checks if a record with the same name already exists for the domain,
-// and returns an error if a duplicate is found. If no duplicate exists, it sends
-// a request to create the record with the provided parameters.
+// It first checks if a record with the same name, type and content already exists
+// for the domain, and returns an error if a duplicate is found. Several records
+// may share a name, e.g. round-robin A records or A and AAAA records on the same
+// host. If no duplicate exists, it sends a request to create the record with the
+// provided parameters.
 //
 // Parameters:
 //   - ctx: The context for the request, used for cancellation and timeouts.
@@ -77,46 +72,42 @@ func (c *RecordClient) ListRecords(ctx context.Context, domain string) ([]schema
 //
 // Returns:
 //   - A pointer to a RecordCreateRequestResponse containing the details of the created record.
-//   - An error if the record creation fails or if a record with the same name already exists.
+//   - An error if the record creation fails or if the same record already exists.
 func (c *RecordClient) CreateRecord(ctx context.Context, r schema.RecordCreateParams) (*schema.RecordCreateRequestResponse, error) {
 	const method string = "add-record"
 	var responseScheme schema.RecordCreateRequestResponse
 
+	if !c.client.skipRecordValidation {
+		if err := ValidateRecord(r); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if the record already exists
-	existingRecords, err := c.ListRecords(context.Background(), r.Domain)
+	existingRecords, err := c.ListRecords(ctx, r.Domain)
 	if err != nil {
 		return nil, err
 	}
 	for _, record := range existingRecords {
-		if record.Name == r.Name {
-			return nil, fmt.Errorf("record with name %s already exists", r.Name)
+		if record.Name == r.Name && record.Type == r.Type && recordDataKey(recordCreateParams(r.Domain, record)) == recordDataKey(r) {
+			return nil, &ResourceError{Err: ErrRecordExists, Domain: r.Domain, ID: fmt.Sprintf("%s %s %s", r.Name, r.Type, r.Content)}
 		}
 	}
 
-	// Create request body
-	body := schema.RecordCreateRequest{
-		Method: method,
-		Params: schema.RecordCreateParams{
-			Domain:       r.Domain,
-			Type:         r.Type,
-			Name:         r.Name,
-			Content:      r.Content,
-			TTL:          r.TTL,
-			Prio:         r.Prio,
-			Weight:       r.Weight,
-			Port:         r.Port,
-			Target:       r.Target,
-			SSHAlgorithm: r.SSHAlgorithm,
-			SSHType:      r.SSHType,
-		},
-	}
-	// Create request
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
-	}
-	// Send request
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	params := schema.RecordCreateParams{
+		Domain:       r.Domain,
+		Type:         r.Type,
+		Name:         r.Name,
+		Content:      r.Content,
+		TTL:          r.TTL,
+		Prio:         r.Prio,
+		Weight:       r.Weight,
+		Port:         r.Port,
+		Target:       r.Target,
+		SSHAlgorithm: r.SSHAlgorithm,
+		SSHType:      r.SSHType,
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -138,54 +129,50 @@ func (c *RecordClient) CreateRecord(ctx context.Context, r schema.RecordCreatePa
 //   - An error if the record does not exist or if there is an issue during the update process.
 //
 // Errors:
-//   - Returns an error if the record with the specified ID does not exist.
+//   - Returns a *ResourceError wrapping ErrRecordNotFound if the record with the specified ID does not exist.
 //   - Returns an error if there is an issue creating or sending the update request.
 func (c *RecordClient) UpdateRecord(ctx context.Context, r schema.RecordUpdateParams) (*schema.RecordUpdateRequestResponse, error) {
 	const method string = "edit-record"
 	var responseScheme schema.RecordUpdateRequestResponse
-	var exists bool
+	var existing *schema.RecordResponse
 
 	// Check if the record exists
 	existingRecords, err := c.ListRecords(ctx, r.Domain)
 	if err != nil {
 		return nil, err
 	}
-	for _, record := range existingRecords {
+	for i, record := range existingRecords {
 		if record.ID == r.ID {
-			exists = true
+			existing = &existingRecords[i]
 			break
 		}
 	}
 
-	if !exists {
-		return nil, fmt.Errorf("record with ID %s does not exist", r.ID)
-	}
-
-	// Create request body
-	body := schema.RecordUpdateRequest{
-		Method: method,
-		Params: schema.RecordUpdateParams{
-			ID:           r.ID,
-			Domain:       r.Domain,
-			Type:         r.Type,
-			Name:         r.Name,
-			Content:      r.Content,
-			TTL:          r.TTL,
-			Prio:         r.Prio,
-			Weight:       r.Weight,
-			Port:         r.Port,
-			Target:       r.Target,
-			SSHAlgorithm: r.SSHAlgorithm,
-			SSHType:      r.SSHType,
-		},
-	}
-	// Create request
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
+	if existing == nil {
+		return nil, &ResourceError{Err: ErrRecordNotFound, Domain: r.Domain, ID: r.ID}
 	}
-	// Send request
-	resp, err := c.client.DoRequest(req, &responseScheme)
+
+	if !c.client.skipRecordValidation {
+		if err := ValidateRecord(updateAsCreateParams(r, *existing)); err != nil {
+			return nil, err
+		}
+	}
+
+	params := schema.RecordUpdateParams{
+		ID:           r.ID,
+		Domain:       r.Domain,
+		Type:         r.Type,
+		Name:         r.Name,
+		Content:      r.Content,
+		TTL:          r.TTL,
+		Prio:         r.Prio,
+		Weight:       r.Weight,
+		Port:         r.Port,
+		Target:       r.Target,
+		SSHAlgorithm: r.SSHAlgorithm,
+		SSHType:      r.SSHType,
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
@@ -224,24 +211,14 @@ func (c *RecordClient) DeleteRecord(ctx context.Context, r schema.RecordDeletePa
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("record with ID %s does not exist", r.ID)
+		return nil, &ResourceError{Err: ErrRecordNotFound, Domain: r.Domain, ID: r.ID}
 	}
 
-	// Create request body
-	body := schema.RecordDeleteRequest{
-		Method: method,
-		Params: schema.RecordDeleteParams{
-			ID:     r.ID,
-			Domain: r.Domain,
-		},
-	}
-	// Create request
-	req, err := c.client.NewRequest(ctx, body)
-	if err != nil {
-		return nil, err
+	params := schema.RecordDeleteParams{
+		ID:     r.ID,
+		Domain: r.Domain,
 	}
-	// Send request
-	resp, err := c.client.DoRequest(req, &responseScheme)
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
 	if err != nil {
 		return nil, err
 	}
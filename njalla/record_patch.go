@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Ptr returns a pointer to v. It is a convenience for filling optional fields
+// such as those of schema.RecordPatchParams and schema.DomainPatchParams.
+//
+// Example usage:
+//
+//	patch := schema.RecordPatchParams{ID: id, Domain: "example.com", Prio: client.Ptr(0)}
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// recordTypeFields lists the optional wire fields used by each record type.
+var recordTypeFields = map[RecordType][]string{
+	RecordTypeMX:    {"prio"},
+	RecordTypeSRV:   {"prio", "weight", "port", "target"},
+	RecordTypeSVCB:  {"prio", "target"},
+	RecordTypeHTTPS: {"prio", "target"},
+	RecordTypeSSHFP: {"ssh_algorithm", "ssh_type"},
+}
+
+// applyRecordPatch returns the record that results from applying p to r.
+func applyRecordPatch(r schema.RecordResponse, p schema.RecordPatchParams) schema.RecordResponse {
+	set := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setInt := func(dst *int, src *int) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&r.Type, p.Type)
+	set(&r.Name, p.Name)
+	set(&r.Content, p.Content)
+	setInt(&r.TTL, p.TTL)
+	setInt(&r.Prio, p.Prio)
+	setInt(&r.Weight, p.Weight)
+	setInt(&r.Port, p.Port)
+	set(&r.Target, p.Target)
+	setInt(&r.SSHAlgorithm, p.SSHAlgorithm)
+	setInt(&r.SSHType, p.SSHType)
+	return r
+}
+
+// recordTypePatch returns a patch that sets the type, name, content and TTL of
+// r and every other field its type uses, zero values included.
+func recordTypePatch(r schema.RecordCreateParams) schema.RecordPatchParams {
+	p := schema.RecordPatchParams{
+		Domain:  r.Domain,
+		Type:    &r.Type,
+		Name:    &r.Name,
+		Content: &r.Content,
+		TTL:     &r.TTL,
+	}
+	for _, field := range recordTypeFields[RecordType(r.Type)] {
+		switch field {
+		case "prio":
+			p.Prio = &r.Prio
+		case "weight":
+			p.Weight = &r.Weight
+		case "port":
+			p.Port = &r.Port
+		case "target":
+			p.Target = &r.Target
+		case "ssh_algorithm":
+			p.SSHAlgorithm = &r.SSHAlgorithm
+		case "ssh_type":
+			p.SSHType = &r.SSHType
+		}
+	}
+	return p
+}
+
+// recordCreateParams converts a record returned by the API back to params.
+func recordCreateParams(domain string, r schema.RecordResponse) schema.RecordCreateParams {
+	return schema.RecordCreateParams{
+		Domain:       domain,
+		Type:         r.Type,
+		Name:         r.Name,
+		Content:      r.Content,
+		TTL:          r.TTL,
+		Prio:         r.Prio,
+		Weight:       r.Weight,
+		Port:         r.Port,
+		Target:       r.Target,
+		SSHAlgorithm: r.SSHAlgorithm,
+		SSHType:      r.SSHType,
+	}
+}
+
+// findPatchTarget returns the record a patch applies to, after checking that the
+// patched record is valid.
+func (c *RecordClient) findPatchTarget(ctx context.Context, p schema.RecordPatchParams) (*schema.RecordResponse, error) {
+	existingRecords, err := c.ListRecords(ctx, p.Domain)
+	if err != nil {
+		return nil, err
+	}
+	var existing *schema.RecordResponse
+	for i, record := range existingRecords {
+		if record.ID == p.ID {
+			existing = &existingRecords[i]
+			break
+		}
+	}
+	if existing == nil {
+		return nil, &ResourceError{Err: ErrRecordNotFound, Domain: p.Domain, ID: p.ID}
+	}
+
+	if !c.client.skipRecordValidation {
+		patched := applyRecordPatch(*existing, p)
+		if err := ValidateRecord(recordCreateParams(p.Domain, patched)); err != nil {
+			return nil, err
+		}
+	}
+	return existing, nil
+}
+
+// PatchRecord updates the fields of an existing DNS record that are set in the
+// patch, and sends nothing else. Unlike UpdateRecord, it can set fields to their
+// zero value, e.g. an MX priority of 0 or an empty TXT content.
+//
+// It first checks that the record exists and, unless validation is disabled,
+// that the patched record is valid.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - p: The patch, identifying the record by domain and ID.
+//
+// Returns:
+//   - A pointer to schema.RecordUpdateRequestResponse containing the updated record.
+//   - An error if the record does not exist, the patched record is invalid, or the request fails.
+func (c *RecordClient) PatchRecord(ctx context.Context, p schema.RecordPatchParams) (*schema.RecordUpdateRequestResponse, error) {
+	const method string = "edit-record"
+	var responseScheme schema.RecordUpdateRequestResponse
+
+	if _, err := c.findPatchTarget(ctx, p); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.call(ctx, method, p, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.RecordUpdateRequestResponse)
+	return response, nil
+}
+
+// MergeRecord applies a patch to an existing DNS record with a read-modify-write
+// cycle: it fetches the current record, applies the fields set in the patch, and
+// sends the merged record, so fields untouched by the patch are preserved even
+// if the API resets omitted fields.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - p: The patch, identifying the record by domain and ID.
+//
+// Returns:
+//   - A pointer to schema.RecordUpdateRequestResponse containing the updated record.
+//   - An error if the record does not exist, the merged record is invalid, or the request fails.
+func (c *RecordClient) MergeRecord(ctx context.Context, p schema.RecordPatchParams) (*schema.RecordUpdateRequestResponse, error) {
+	const method string = "edit-record"
+	var responseScheme schema.RecordUpdateRequestResponse
+
+	existing, err := c.findPatchTarget(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send every field the record has or its type uses, plus every field the patch touches.
+	merged := applyRecordPatch(*existing, p)
+	uses := recordTypeFields[RecordType(merged.Type)]
+	params := schema.RecordPatchParams{
+		ID:      p.ID,
+		Domain:  p.Domain,
+		Type:    &merged.Type,
+		Name:    &merged.Name,
+		Content: &merged.Content,
+		TTL:     &merged.TTL,
+	}
+	keepInt := func(field string, patched *int, current int, merged *int) *int {
+		if patched != nil || current != 0 || slices.Contains(uses, field) {
+			return merged
+		}
+		return nil
+	}
+	params.Prio = keepInt("prio", p.Prio, existing.Prio, &merged.Prio)
+	params.Weight = keepInt("weight", p.Weight, existing.Weight, &merged.Weight)
+	params.Port = keepInt("port", p.Port, existing.Port, &merged.Port)
+	params.SSHAlgorithm = keepInt("ssh_algorithm", p.SSHAlgorithm, existing.SSHAlgorithm, &merged.SSHAlgorithm)
+	params.SSHType = keepInt("ssh_type", p.SSHType, existing.SSHType, &merged.SSHType)
+	if p.Target != nil || existing.Target != "" || slices.Contains(uses, "target") {
+		params.Target = &merged.Target
+	}
+
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.RecordUpdateRequestResponse)
+	return response, nil
+}
@@ -0,0 +1,125 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// patchRecords are the records every patch test starts from. Seed assigns
+// them the IDs "1" and "2".
+var patchRecords = []schema.RecordResponse{
+	{Name: "@", Type: "MX", Content: "mail.example.com", Prio: 10, TTL: 3600},
+	{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+}
+
+func TestPatchAndMergeRecord(t *testing.T) {
+	tests := []struct {
+		name       string
+		merge      bool
+		patch      schema.RecordPatchParams
+		wantFields []string
+		want       schema.RecordResponse
+	}{
+		{
+			name:       "patch sets priority to zero",
+			patch:      schema.RecordPatchParams{ID: "1", Prio: client.Ptr(0)},
+			wantFields: []string{"domain", "id", "prio"},
+			want:       schema.RecordResponse{Name: "@", Type: "MX", Content: "mail.example.com", Prio: 0, TTL: 3600},
+		},
+		{
+			name:       "patch sends only the set fields",
+			patch:      schema.RecordPatchParams{ID: "2", TTL: client.Ptr(300)},
+			wantFields: []string{"domain", "id", "ttl"},
+			want:       schema.RecordResponse{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 300},
+		},
+		{
+			name:       "merge sends the whole MX record",
+			merge:      true,
+			patch:      schema.RecordPatchParams{ID: "1", Content: client.Ptr("mx.example.com")},
+			wantFields: []string{"content", "domain", "id", "name", "prio", "ttl", "type"},
+			want:       schema.RecordResponse{Name: "@", Type: "MX", Content: "mx.example.com", Prio: 10, TTL: 3600},
+		},
+		{
+			name:       "merge leaves out fields the type does not use",
+			merge:      true,
+			patch:      schema.RecordPatchParams{ID: "2", Content: client.Ptr("192.0.2.2")},
+			wantFields: []string{"content", "domain", "id", "name", "ttl", "type"},
+			want:       schema.RecordResponse{Name: "www", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, patchRecords...)
+			c := srv.Client()
+			tt.patch.Domain = "example.com"
+
+			var err error
+			if tt.merge {
+				_, err = c.Record.MergeRecord(context.Background(), tt.patch)
+			} else {
+				_, err = c.Record.PatchRecord(context.Background(), tt.patch)
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			requests := srv.RequestsFor("edit-record")
+			if len(requests) != 1 {
+				t.Fatalf("edit-record requests = %d, want 1", len(requests))
+			}
+			var params map[string]json.RawMessage
+			if err := json.Unmarshal(requests[0].Params, &params); err != nil {
+				t.Fatalf("failed to decode params: %v", err)
+			}
+			if got := slices.Sorted(maps.Keys(params)); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("sent fields = %v, want %v", got, tt.wantFields)
+			}
+
+			for _, record := range srv.Records("example.com") {
+				if record.ID != tt.patch.ID {
+					continue
+				}
+				record.Extra, tt.want.ID = nil, record.ID
+				if !reflect.DeepEqual(record, tt.want) {
+					t.Errorf("stored record = %+v, want %+v", record, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchRecordErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch schema.RecordPatchParams
+		check func(error) bool
+	}{
+		{"unknown record", schema.RecordPatchParams{ID: "42", TTL: client.Ptr(300)}, client.IsNotFound},
+		{"invalid result", schema.RecordPatchParams{ID: "2", Content: client.Ptr("not an address")}, func(err error) bool {
+			var validationErr *client.ValidationError
+			return errors.As(err, &validationErr)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, patchRecords...)
+			c := srv.Client()
+			tt.patch.Domain = "example.com"
+
+			if _, err := c.Record.PatchRecord(context.Background(), tt.patch); !tt.check(err) {
+				t.Errorf("PatchRecord() error = %v", err)
+			}
+			if got := len(srv.RequestsFor("edit-record")); got != 0 {
+				t.Errorf("edit-record requests = %d, want 0", got)
+			}
+		})
+	}
+}
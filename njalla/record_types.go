@@ -0,0 +1,508 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// RecordHeader holds the fields shared by every record type.
+//
+// Fields:
+//   - ID: The ID assigned by the API. It is empty for records not created yet.
+//   - Name: The name of the record relative to the domain, e.g. "@" or "www".
+//   - TTL: The time to live in seconds. Zero leaves the choice to the API.
+type RecordHeader struct {
+	ID   string
+	Name string
+	TTL  int
+}
+
+// Header returns the record header.
+func (h RecordHeader) Header() RecordHeader {
+	return h
+}
+
+// Record is a strongly typed DNS record. It is implemented by ARecord,
+// AAAARecord, ANAMERecord, CAARecord, CNAMERecord, DynamicRecord, HTTPSRecord,
+// MXRecord, NAPTRRecord, NSRecord, PTRRecord, SRVRecord, SSHFPRecord,
+// SVCBRecord, TLSARecord and TXTRecord.
+//
+// Use NewRecordCreateParams and NewRecordUpdateParams to convert a Record to
+// the wire params, and RecordFromResponse to convert an API response back.
+type Record interface {
+	Type() RecordType
+	Header() RecordHeader
+	// params returns the type specific wire fields of the record.
+	params() schema.RecordCreateParams
+}
+
+// ARecord maps a name to an IPv4 address.
+type ARecord struct {
+	RecordHeader
+	IP netip.Addr
+}
+
+// AAAARecord maps a name to an IPv6 address.
+type AAAARecord struct {
+	RecordHeader
+	IP netip.Addr
+}
+
+// ANAMERecord aliases a name, including the zone apex, to another host name.
+type ANAMERecord struct {
+	RecordHeader
+	Target string
+}
+
+// CNAMERecord aliases a name to another host name.
+type CNAMERecord struct {
+	RecordHeader
+	Target string
+}
+
+// NSRecord delegates a name to a name server.
+type NSRecord struct {
+	RecordHeader
+	Host string
+}
+
+// PTRRecord maps a name to a host name, usually for reverse lookups.
+type PTRRecord struct {
+	RecordHeader
+	Target string
+}
+
+// TXTRecord holds arbitrary text.
+type TXTRecord struct {
+	RecordHeader
+	Text string
+}
+
+// DynamicRecord is a dynamic DNS record, updated through Njalla's dynamic DNS
+// service. Content holds the value reported by the API.
+type DynamicRecord struct {
+	RecordHeader
+	Content string
+}
+
+// MXRecord names a mail exchanger for the domain.
+type MXRecord struct {
+	RecordHeader
+	Priority int
+	Host     string
+}
+
+// SRVRecord names the host and port of a service.
+type SRVRecord struct {
+	RecordHeader
+	Priority int
+	Weight   int
+	Port     int
+	Target   string
+}
+
+// SSHFPRecord publishes the fingerprint of an SSH host key.
+//
+// Fields:
+//   - Algorithm: The key algorithm, e.g. 4 for Ed25519.
+//   - FingerprintType: The fingerprint hash, e.g. 2 for SHA-256.
+//   - Fingerprint: The fingerprint as a hex string.
+type SSHFPRecord struct {
+	RecordHeader
+	Algorithm       int
+	FingerprintType int
+	Fingerprint     string
+}
+
+// TLSARecord associates a TLS certificate or public key with a service.
+//
+// Fields:
+//   - Usage: The certificate usage, 0 to 3.
+//   - Selector: Whether the full certificate (0) or its public key (1) is matched.
+//   - MatchingType: Whether the data is the exact value (0), a SHA-256 (1) or a SHA-512 (2) hash.
+//   - Data: The certificate association data as a hex string.
+type TLSARecord struct {
+	RecordHeader
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+	Data         string
+}
+
+// CAARecord restricts which certificate authorities may issue certificates for the name.
+//
+// Fields:
+//   - Flags: The record flags; 128 marks the tag as critical.
+//   - Tag: The property tag, e.g. "issue", "issuewild" or "iodef".
+//   - Value: The property value, e.g. "letsencrypt.org".
+type CAARecord struct {
+	RecordHeader
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// SVCBRecord describes the endpoint of a service.
+//
+// Fields:
+//   - Priority: The priority; 0 marks an alias record.
+//   - Target: The target host name, or "." for the owner name.
+//   - Params: The service parameters in presentation format, e.g. "alpn=h2,h3".
+type SVCBRecord struct {
+	RecordHeader
+	Priority int
+	Target   string
+	Params   string
+}
+
+// HTTPSRecord describes the endpoint of an HTTPS service. Its fields have the
+// same meaning as those of SVCBRecord.
+type HTTPSRecord struct {
+	RecordHeader
+	Priority int
+	Target   string
+	Params   string
+}
+
+// NAPTRRecord holds a naming authority pointer, used for URI rewriting.
+type NAPTRRecord struct {
+	RecordHeader
+	Order       int
+	Preference  int
+	Flags       string
+	Service     string
+	Regexp      string
+	Replacement string
+}
+
+func (ARecord) Type() RecordType       { return RecordTypeA }
+func (AAAARecord) Type() RecordType    { return RecordTypeAAAA }
+func (ANAMERecord) Type() RecordType   { return RecordTypeANAME }
+func (CNAMERecord) Type() RecordType   { return RecordTypeCNAME }
+func (NSRecord) Type() RecordType      { return RecordTypeNS }
+func (PTRRecord) Type() RecordType     { return RecordTypePTR }
+func (TXTRecord) Type() RecordType     { return RecordTypeTXT }
+func (DynamicRecord) Type() RecordType { return RecordTypeDynamic }
+func (MXRecord) Type() RecordType      { return RecordTypeMX }
+func (SRVRecord) Type() RecordType     { return RecordTypeSRV }
+func (SSHFPRecord) Type() RecordType   { return RecordTypeSSHFP }
+func (TLSARecord) Type() RecordType    { return RecordTypeTLSA }
+func (CAARecord) Type() RecordType     { return RecordTypeCAA }
+func (SVCBRecord) Type() RecordType    { return RecordTypeSVCB }
+func (HTTPSRecord) Type() RecordType   { return RecordTypeHTTPS }
+func (NAPTRRecord) Type() RecordType   { return RecordTypeNAPTR }
+
+func (r ARecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.IP.String()}
+}
+
+func (r AAAARecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.IP.String()}
+}
+
+func (r ANAMERecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Target}
+}
+
+func (r CNAMERecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Target}
+}
+
+func (r NSRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Host}
+}
+
+func (r PTRRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Target}
+}
+
+func (r TXTRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Text}
+}
+
+func (r DynamicRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: r.Content}
+}
+
+func (r MXRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Prio: r.Priority, Content: r.Host}
+}
+
+func (r SRVRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Prio: r.Priority, Weight: r.Weight, Port: r.Port, Target: r.Target}
+}
+
+func (r SSHFPRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{SSHAlgorithm: r.Algorithm, SSHType: r.FingerprintType, Content: r.Fingerprint}
+}
+
+func (r TLSARecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: fmt.Sprintf("%d %d %d %s", r.Usage, r.Selector, r.MatchingType, r.Data)}
+}
+
+func (r CAARecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: fmt.Sprintf("%d %s %s", r.Flags, r.Tag, strconv.Quote(r.Value))}
+}
+
+func (r SVCBRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Prio: r.Priority, Target: r.Target, Content: r.Params}
+}
+
+func (r HTTPSRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Prio: r.Priority, Target: r.Target, Content: r.Params}
+}
+
+func (r NAPTRRecord) params() schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: fmt.Sprintf("%d %d %s %s %s %s",
+		r.Order, r.Preference, strconv.Quote(r.Flags), strconv.Quote(r.Service), strconv.Quote(r.Regexp), r.Replacement)}
+}
+
+// NewRecordCreateParams converts a typed record to the params of an "add-record" call.
+//
+// Parameters:
+//   - domain: The domain the record belongs to.
+//   - r: The typed record.
+//
+// Returns:
+//   - The schema.RecordCreateParams describing the record.
+func NewRecordCreateParams(domain string, r Record) schema.RecordCreateParams {
+	params := r.params()
+	header := r.Header()
+	params.Domain = domain
+	params.Type = string(r.Type())
+	params.Name = header.Name
+	params.TTL = header.TTL
+	return params
+}
+
+// NewRecordUpdateParams converts a typed record to the params of an "edit-record"
+// call. The record is identified by the ID in its header. The name, the content
+// and every other field the record type uses are set, zero values included, so
+// an MXRecord with priority 0 is sent with a priority of 0. The TTL is only set
+// if it is not zero.
+//
+// Parameters:
+//   - domain: The domain the record belongs to.
+//   - r: The typed record.
+//
+// Returns:
+//   - The schema.RecordPatchParams describing the record, for PatchRecord.
+func NewRecordUpdateParams(domain string, r Record) schema.RecordPatchParams {
+	params := recordTypePatch(NewRecordCreateParams(domain, r))
+	params.ID = r.Header().ID
+	if *params.TTL == 0 {
+		params.TTL = nil
+	}
+	return params
+}
+
+// RecordFromResponse converts a record returned by the API to a typed record.
+//
+// Parameters:
+//   - r: The record as returned by the API.
+//
+// Returns:
+//   - The typed record.
+//   - An error if the record type is unknown or its content cannot be parsed.
+func RecordFromResponse(r schema.RecordResponse) (Record, error) {
+	header := RecordHeader{ID: r.ID, Name: r.Name, TTL: r.TTL}
+	switch RecordType(r.Type) {
+	case RecordTypeA, RecordTypeAAAA:
+		ip, err := netip.ParseAddr(r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s record %s: %w", r.Type, r.ID, err)
+		}
+		if RecordType(r.Type) == RecordTypeA {
+			return ARecord{RecordHeader: header, IP: ip}, nil
+		}
+		return AAAARecord{RecordHeader: header, IP: ip}, nil
+	case RecordTypeANAME:
+		return ANAMERecord{RecordHeader: header, Target: r.Content}, nil
+	case RecordTypeCNAME:
+		return CNAMERecord{RecordHeader: header, Target: r.Content}, nil
+	case RecordTypeNS:
+		return NSRecord{RecordHeader: header, Host: r.Content}, nil
+	case RecordTypePTR:
+		return PTRRecord{RecordHeader: header, Target: r.Content}, nil
+	case RecordTypeTXT:
+		return TXTRecord{RecordHeader: header, Text: r.Content}, nil
+	case RecordTypeDynamic:
+		return DynamicRecord{RecordHeader: header, Content: r.Content}, nil
+	case RecordTypeMX:
+		return MXRecord{RecordHeader: header, Priority: r.Prio, Host: r.Content}, nil
+	case RecordTypeSRV:
+		return SRVRecord{RecordHeader: header, Priority: r.Prio, Weight: r.Weight, Port: r.Port, Target: r.Target}, nil
+	case RecordTypeSSHFP:
+		return SSHFPRecord{RecordHeader: header, Algorithm: r.SSHAlgorithm, FingerprintType: r.SSHType, Fingerprint: r.Content}, nil
+	case RecordTypeTLSA:
+		return parseTLSA(header, r.Content)
+	case RecordTypeCAA:
+		return parseCAA(header, r.Content)
+	case RecordTypeSVCB:
+		return SVCBRecord{RecordHeader: header, Priority: r.Prio, Target: r.Target, Params: r.Content}, nil
+	case RecordTypeHTTPS:
+		return HTTPSRecord{RecordHeader: header, Priority: r.Prio, Target: r.Target, Params: r.Content}, nil
+	case RecordTypeNAPTR:
+		return parseNAPTR(header, r.Content)
+	default:
+		return nil, fmt.Errorf("unknown record type %s", r.Type)
+	}
+}
+
+// parseTLSA parses the content of a TLSA record: "usage selector matching-type data".
+func parseTLSA(header RecordHeader, content string) (Record, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("invalid TLSA record %s: expected 4 fields, got %d", header.ID, len(fields))
+	}
+	var values [3]uint8
+	for i := range values {
+		v, err := strconv.ParseUint(fields[i], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLSA record %s: %w", header.ID, err)
+		}
+		values[i] = uint8(v)
+	}
+	return TLSARecord{RecordHeader: header, Usage: values[0], Selector: values[1], MatchingType: values[2], Data: fields[3]}, nil
+}
+
+// parseCAA parses the content of a CAA record: `flags tag "value"`.
+func parseCAA(header RecordHeader, content string) (Record, error) {
+	fields, err := splitQuoted(content)
+	if err != nil || len(fields) != 3 {
+		return nil, fmt.Errorf("invalid CAA record %s: %q", header.ID, content)
+	}
+	flags, err := strconv.ParseUint(fields[0], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CAA record %s: %w", header.ID, err)
+	}
+	return CAARecord{RecordHeader: header, Flags: uint8(flags), Tag: fields[1], Value: fields[2]}, nil
+}
+
+// parseNAPTR parses the content of a NAPTR record:
+// `order preference "flags" "service" "regexp" replacement`.
+func parseNAPTR(header RecordHeader, content string) (Record, error) {
+	fields, err := splitQuoted(content)
+	if err != nil || len(fields) != 6 {
+		return nil, fmt.Errorf("invalid NAPTR record %s: %q", header.ID, content)
+	}
+	order, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid NAPTR record %s: %w", header.ID, err)
+	}
+	preference, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid NAPTR record %s: %w", header.ID, err)
+	}
+	return NAPTRRecord{
+		RecordHeader: header,
+		Order:        order,
+		Preference:   preference,
+		Flags:        fields[2],
+		Service:      fields[3],
+		Regexp:       fields[4],
+		Replacement:  fields[5],
+	}, nil
+}
+
+// splitQuoted splits s into whitespace separated fields. Fields may be
+// enclosed in double quotes, in which case they may contain whitespace and
+// backslash escapes, and the quotes are removed.
+func splitQuoted(s string) ([]string, error) {
+	var fields []string
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return fields, nil
+		}
+		if s[0] != '"' {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+			fields = append(fields, s[:end])
+			s = s[end:]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		field, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		s = s[len(quoted):]
+	}
+}
+
+// ListTypedRecords retrieves the DNS records of the specified domain as typed records.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name for which to retrieve DNS records.
+//
+// Returns:
+//   - []Record: The typed DNS records of the domain.
+//   - error: An error if the request fails or a record cannot be converted.
+func (c *RecordClient) ListTypedRecords(ctx context.Context, domain string) ([]Record, error) {
+	records, err := c.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	typed := make([]Record, 0, len(records))
+	for _, record := range records {
+		r, err := RecordFromResponse(record)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, r)
+	}
+	return typed, nil
+}
+
+// CreateTypedRecord creates a new DNS record for the specified domain from a
+// typed record. It performs the same checks as CreateRecord.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain the record belongs to.
+//   - r: The typed record to create.
+//
+// Returns:
+//   - The created record, including the ID assigned by the API.
+//   - An error if the record creation fails.
+func (c *RecordClient) CreateTypedRecord(ctx context.Context, domain string, r Record) (Record, error) {
+	resp, err := c.CreateRecord(ctx, NewRecordCreateParams(domain, r))
+	if err != nil {
+		return nil, err
+	}
+	return RecordFromResponse(*resp)
+}
+
+// UpdateTypedRecord updates an existing DNS record of the specified domain from
+// a typed record. The record is identified by the ID in its header. It sends the
+// params built by NewRecordUpdateParams with PatchRecord, and performs the same
+// checks.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain the record belongs to.
+//   - r: The typed record holding the new values.
+//
+// Returns:
+//   - The updated record.
+//   - An error if the record does not exist or the update fails.
+func (c *RecordClient) UpdateTypedRecord(ctx context.Context, domain string, r Record) (Record, error) {
+	resp, err := c.PatchRecord(ctx, NewRecordUpdateParams(domain, r))
+	if err != nil {
+		return nil, err
+	}
+	return RecordFromResponse(*resp)
+}
@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestTypedRecordRoundTrip(t *testing.T) {
+	header := client.RecordHeader{ID: "1", Name: "www", TTL: 300}
+	tests := []struct {
+		name   string
+		record client.Record
+	}{
+		{"A", client.ARecord{RecordHeader: header, IP: netip.MustParseAddr("192.0.2.1")}},
+		{"AAAA", client.AAAARecord{RecordHeader: header, IP: netip.MustParseAddr("2001:db8::1")}},
+		{"ANAME", client.ANAMERecord{RecordHeader: header, Target: "example.net"}},
+		{"CNAME", client.CNAMERecord{RecordHeader: header, Target: "example.net"}},
+		{"NS", client.NSRecord{RecordHeader: header, Host: "ns1.example.net"}},
+		{"PTR", client.PTRRecord{RecordHeader: header, Target: "host.example.com"}},
+		{"TXT with spaces and quotes", client.TXTRecord{RecordHeader: header, Text: `v=spf1 include:"_spf.example.com" -all`}},
+		{"Dynamic", client.DynamicRecord{RecordHeader: header, Content: "192.0.2.7"}},
+		{"MX", client.MXRecord{RecordHeader: header, Priority: 10, Host: "mx.example.com"}},
+		{"MX with priority 0", client.MXRecord{RecordHeader: header, Host: "mx.example.com"}},
+		{"SRV", client.SRVRecord{RecordHeader: header, Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}},
+		{"SSHFP", client.SSHFPRecord{RecordHeader: header, Algorithm: 4, FingerprintType: 2, Fingerprint: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}},
+		{"TLSA", client.TLSARecord{RecordHeader: header, Usage: 3, Selector: 1, MatchingType: 1, Data: "2bb183af3e7b0b2e6f1b4e0c1f6b5a6d"}},
+		{"CAA", client.CAARecord{RecordHeader: header, Flags: 0, Tag: "issue", Value: "letsencrypt.org"}},
+		{"CAA with spaces and quotes", client.CAARecord{RecordHeader: header, Flags: 128, Tag: "iodef", Value: `mailto:dns "team" @example.com; note=a b`}},
+		{"SVCB", client.SVCBRecord{RecordHeader: header, Priority: 1, Target: "svc.example.com", Params: "alpn=h2,h3 port=8443"}},
+		{"HTTPS alias", client.HTTPSRecord{RecordHeader: header, Priority: 0, Target: "example.net"}},
+		{"NAPTR", client.NAPTRRecord{RecordHeader: header, Order: 100, Preference: 10, Flags: "u", Service: "E2U+sip", Regexp: "!^.*$!sip:info@example.com!", Replacement: "."}},
+		{"NAPTR with spaces in the regexp", client.NAPTRRecord{RecordHeader: header, Order: 100, Preference: 20, Flags: "", Service: "E2U+web:http", Regexp: `!^(.*)$!http://example.com/a b\1!`, Replacement: "."}},
+	}
+	seen := map[client.RecordType]bool{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen[tt.record.Type()] = true
+			params := client.NewRecordCreateParams("example.com", tt.record)
+			if params.Domain != "example.com" || params.Type != string(tt.record.Type()) || params.Name != "www" || params.TTL != 300 {
+				t.Errorf("NewRecordCreateParams() = %+v, want the domain, type and header", params)
+			}
+
+			// Send the params through JSON, as the API would echo them back.
+			data, err := json.Marshal(params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var resp schema.RecordResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				t.Fatal(err)
+			}
+			resp.ID = header.ID
+
+			got, err := client.RecordFromResponse(resp)
+			if err != nil {
+				t.Fatalf("RecordFromResponse(%+v) error = %v", resp, err)
+			}
+			if !reflect.DeepEqual(got, tt.record) {
+				t.Errorf("round trip = %#v, want %#v", got, tt.record)
+			}
+		})
+	}
+	if len(seen) != 16 {
+		t.Errorf("covered %d record types, want 16", len(seen))
+	}
+}
+
+func TestRecordFromResponseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		content string
+	}{
+		{"unknown type", "SPF", "v=spf1 -all"},
+		{"invalid address", "A", "192.0.2"},
+		{"IPv6 as A", "AAAA", "not an address"},
+		{"TLSA with three fields", "TLSA", "3 1 1"},
+		{"TLSA with five fields", "TLSA", "3 1 1 abcd ef"},
+		{"TLSA usage not a number", "TLSA", "x 1 1 abcd"},
+		{"TLSA usage out of range", "TLSA", "256 1 1 abcd"},
+		{"CAA with two fields", "CAA", "0 issue"},
+		{"CAA with an unterminated quote", "CAA", `0 issue "letsencrypt.org`},
+		{"CAA flags not a number", "CAA", `x issue "letsencrypt.org"`},
+		{"CAA with an unquoted value with spaces", "CAA", "0 issue letsencrypt.org extra"},
+		{"NAPTR with four fields", "NAPTR", `100 10 "u" "E2U+sip"`},
+		{"NAPTR order not a number", "NAPTR", `first 10 "u" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{"NAPTR preference not a number", "NAPTR", `100 x "u" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+		{"NAPTR with an invalid escape", "NAPTR", `100 10 "u" "E2U+sip" "\q" .`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := schema.RecordResponse{ID: "1", Name: "www", Type: tt.typ, Content: tt.content}
+			if got, err := client.RecordFromResponse(resp); err == nil {
+				t.Errorf("RecordFromResponse() = %#v, want an error", got)
+			}
+		})
+	}
+}
+
+func TestUpdateTypedRecordSendsZeroPriority(t *testing.T) {
+	srv := newRecordServer(t, schema.RecordResponse{Name: "@", Type: "MX", Content: "mx.example.com", Prio: 10, TTL: 3600})
+	c := srv.Client()
+
+	record := client.MXRecord{RecordHeader: client.RecordHeader{ID: "1", Name: "@"}, Host: "mx.example.com"}
+	got, err := c.Record.UpdateTypedRecord(context.Background(), "example.com", record)
+	if err != nil {
+		t.Fatalf("UpdateTypedRecord() error = %v", err)
+	}
+	if mx, ok := got.(client.MXRecord); !ok || mx.Priority != 0 || mx.TTL != 3600 {
+		t.Errorf("UpdateTypedRecord() = %#v, want priority 0 and the TTL kept", got)
+	}
+
+	requests := srv.RequestsFor("edit-record")
+	if len(requests) != 1 {
+		t.Fatalf("edit-record requests = %d, want 1", len(requests))
+	}
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(requests[0].Params, &params); err != nil {
+		t.Fatal(err)
+	}
+	if string(params["prio"]) != "0" {
+		t.Errorf("sent prio = %s, want 0", params["prio"])
+	}
+	if _, ok := params["ttl"]; ok {
+		t.Errorf("sent ttl = %s, want it left out", params["ttl"])
+	}
+}
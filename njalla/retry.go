@@ -0,0 +1,186 @@
+package client
+
+import (
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// A request is retried when the HTTP round trip fails, or when the server
+// responds with a 5xx or 429 status code. JSON-RPC errors returned with a
+// 200 status code are never retried.
+//
+// Only idempotent read methods ("list-*", "get-*", "find-*" and "check-*")
+// are retried, unless RetryMutations is set. Every attempt and every wait
+// between attempts respects the request context.
+//
+// Fields:
+//   - MaxAttempts: The maximum number of attempts, including the first one.
+//     Values below 2 disable retries.
+//   - InitialBackoff: The delay before the second attempt.
+//   - MaxBackoff: The upper bound for the delay between attempts. Zero means no bound.
+//   - Multiplier: The factor the delay grows by after each attempt. Values below 1 are treated as 1.
+//   - Jitter: The fraction (0 to 1) of each delay that is randomized, to avoid
+//     synchronized retries from many clients.
+//   - RetryMutations: Whether non-idempotent methods such as "add-record" may be retried.
+//
+// If the server sends a Retry-After header, its value is used instead of the
+// computed delay, capped at MaxBackoff if that is set.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	RetryMutations bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults: up to four
+// attempts, starting at 250ms and doubling up to 5s, with 20% jitter.
+// Mutations are not retried.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryPolicy sets the retry policy used for every request sent by the client.
+// By default, requests are attempted only once.
+//
+// Parameters:
+//   - policy: The retry policy to apply.
+//
+// Returns:
+//
+//	A ClientOption that applies the specified retry policy to a Client instance.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = policy
+	}
+}
+
+// isIdempotent reports whether a JSON-RPC method only reads state and is
+// therefore safe to retry.
+func isIdempotent(method string) bool {
+	for _, prefix := range []string{"list-", "get-", "find-", "check-"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// attempts returns the number of attempts allowed for the given method.
+func (p RetryPolicy) attempts(method string) int {
+	if p.MaxAttempts < 2 {
+		return 1
+	}
+	if !p.RetryMutations && !isIdempotent(method) {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before the attempt following the given one.
+// A Retry-After header on resp takes precedence over the computed delay, but
+// is capped at MaxBackoff too.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 {
+				d = min(d, p.MaxBackoff)
+			}
+			return d
+		}
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		d -= d * jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// retryable reports whether the outcome of an attempt warrants another one.
+func retryable(r *http.Request, resp *http.Response, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// send performs the HTTP round trip for r, retrying according to the client's
+// retry policy. The returned response is the one from the last attempt.
+func (c *Client) send(r *http.Request, method string) (*http.Response, error) {
+	attempts := c.retryPolicy.attempts(method)
+	if r.GetBody == nil {
+		attempts = 1
+	}
+
+	req := r
+	for attempt := 1; ; attempt++ {
+		countAttempt(r.Context())
+		resp, err := c.httpClient.Do(req)
+		if attempt >= attempts || !retryable(r, resp, err) {
+			return resp, err
+		}
+
+		wait := c.retryPolicy.backoff(attempt, resp)
+		var status int
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.logRetry(r.Context(), method, attempt, wait, status, err)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-r.Context().Done():
+			timer.Stop()
+			return nil, r.Context().Err()
+		case <-timer.C:
+		}
+
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req = r.Clone(r.Context())
+		req.Body = body
+	}
+}
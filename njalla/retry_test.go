@@ -0,0 +1,99 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := client.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+	mutations := policy
+	mutations.RetryMutations = true
+
+	tests := []struct {
+		name         string
+		policy       client.RetryPolicy
+		mutation     bool
+		status       int
+		body         string
+		wantRequests int32
+	}{
+		{"read retried on 5xx", policy, false, http.StatusServiceUnavailable, "", 3},
+		{"read retried on 429", policy, false, http.StatusTooManyRequests, "", 3},
+		{"read not retried on 4xx", policy, false, http.StatusBadRequest, "", 1},
+		{"read not retried on JSON-RPC error", policy, false, http.StatusOK, `{"error":{"code":500,"message":"boom"}}`, 1},
+		{"mutation not retried", policy, true, http.StatusServiceUnavailable, "", 1},
+		{"mutation retried when allowed", mutations, true, http.StatusServiceUnavailable, "", 3},
+		{"retries disabled", client.RetryPolicy{MaxAttempts: 1}, false, http.StatusServiceUnavailable, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := "list-domains"
+			if tt.mutation {
+				method = "add-record"
+			}
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Method string `json:"method"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.Method != method {
+					fmt.Fprint(w, `{"result":{"records":[]}}`)
+					return
+				}
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+			c := client.NewClient(client.WithEndpoint(srv.URL), client.WithRetryPolicy(tt.policy))
+
+			var err error
+			if tt.mutation {
+				_, err = c.Record.CreateRecord(context.Background(), schema.RecordCreateParams{Domain: "example.com", Type: "A", Name: "www", Content: "192.0.2.1"})
+			} else {
+				_, err = c.Domain.ListDomains(context.Background())
+			}
+			if err == nil {
+				t.Fatal("error = nil, want the error of the last attempt")
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryAfterIsCappedAtMaxBackoff(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"result":{"domains":[]}}`)
+	}))
+	defer srv.Close()
+	policy := client.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	c := client.NewClient(client.WithEndpoint(srv.URL), client.WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.Domain.ListDomains(ctx); err != nil {
+		t.Fatalf("ListDomains() error = %v, want the retry to wait at most MaxBackoff", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
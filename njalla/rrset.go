@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// RRset is the set of records of a domain sharing a name and a type, e.g. all
+// A records of "www" or all MX records of the zone apex.
+type RRset struct {
+	Domain  string
+	Name    string
+	Type    RecordType
+	Records []schema.RecordResponse
+}
+
+// recordDataKey identifies the data of a record within an RRset. Records with
+// the same name, type and key are duplicates, whatever their TTL.
+func recordDataKey(r schema.RecordCreateParams) string {
+	return fmt.Sprintf("%s|%d|%d|%d|%s|%d|%d", r.Content, r.Prio, r.Weight, r.Port, r.Target, r.SSHAlgorithm, r.SSHType)
+}
+
+// filterRRset returns the records with the given name and type.
+func filterRRset(records []schema.RecordResponse, name string, recordType RecordType) []schema.RecordResponse {
+	var members []schema.RecordResponse
+	for _, record := range records {
+		if record.Name == name && record.Type == string(recordType) {
+			members = append(members, record)
+		}
+	}
+	return members
+}
+
+// GetRRset retrieves the records of a domain with the given name and type.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//   - name: The record name, e.g. "@" or "www".
+//   - recordType: The record type.
+//
+// Returns:
+//   - A pointer to the RRset. Its Records are empty if no record matches.
+//   - An error if the request fails.
+func (c *RecordClient) GetRRset(ctx context.Context, domain, name string, recordType RecordType) (*RRset, error) {
+	records, err := c.ListRecords(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	return &RRset{
+		Domain:  domain,
+		Name:    name,
+		Type:    recordType,
+		Records: filterRRset(records, name, recordType),
+	}, nil
+}
+
+// ReplaceRRset makes the records of a domain with the given name and type match
+// members exactly. Members that already exist are kept (and their TTL updated
+// if needed), missing members are added, and records not among the members are
+// removed. An empty members list removes the whole RRset.
+//
+// New members are added before old ones are removed, so resolvers never see an
+// empty set while it changes. If adding a member fails, the members added so far
+// are removed again, even if ctx is done; if that rollback succeeds, the RRset
+// is left as it was. Rollback failures are joined into the returned error, in
+// which case some added members may remain.
+//
+// The Domain, Name and Type of each member are ignored and taken from the
+// arguments instead. Unless validation is disabled, every member is validated
+// before any change is made.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - domain: The domain name.
+//   - name: The record name, e.g. "@" or "www".
+//   - recordType: The record type.
+//   - members: The records the RRset should consist of.
+//
+// Returns:
+//   - A pointer to the resulting RRset.
+//   - An error if a member is invalid or a request fails.
+func (c *RecordClient) ReplaceRRset(ctx context.Context, domain, name string, recordType RecordType, members []schema.RecordCreateParams) (*RRset, error) {
+	wanted := make(map[string]schema.RecordCreateParams, len(members))
+	var order []string
+	for _, member := range members {
+		member.Domain, member.Name, member.Type = domain, name, string(recordType)
+		if !c.client.skipRecordValidation {
+			if err := ValidateRecord(member); err != nil {
+				return nil, err
+			}
+		}
+		key := recordDataKey(member)
+		if _, ok := wanted[key]; !ok {
+			order = append(order, key)
+		}
+		wanted[key] = member
+	}
+
+	current, err := c.GetRRset(ctx, domain, name, recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept, stale []schema.RecordResponse
+	existing := make(map[string]bool, len(current.Records))
+	for _, record := range current.Records {
+		key := recordDataKey(recordCreateParams(domain, record))
+		if _, ok := wanted[key]; ok && !existing[key] {
+			existing[key] = true
+			kept = append(kept, record)
+		} else {
+			stale = append(stale, record)
+		}
+	}
+
+	// Add the missing members, rolling back on failure.
+	var added []schema.RecordResponse
+	for _, key := range order {
+		if existing[key] {
+			continue
+		}
+		var created schema.RecordCreateRequestResponse
+		if _, err := c.client.call(ctx, "add-record", wanted[key], &created); err != nil {
+			err = fmt.Errorf("failed to add %s record %s: %w", recordType, name, err)
+			return nil, errors.Join(err, c.rollbackRRset(ctx, domain, added))
+		}
+		added = append(added, created)
+	}
+
+	// Update the TTL of the kept members.
+	result := &RRset{Domain: domain, Name: name, Type: recordType}
+	var errs []error
+	for _, record := range kept {
+		member := wanted[recordDataKey(recordCreateParams(domain, record))]
+		if member.TTL != 0 && member.TTL != record.TTL {
+			var updated schema.RecordUpdateRequestResponse
+			patch := schema.RecordPatchParams{ID: record.ID, Domain: domain, TTL: &member.TTL}
+			if _, err := c.client.call(ctx, "edit-record", patch, &updated); err != nil {
+				errs = append(errs, err)
+			} else {
+				record.TTL = member.TTL
+			}
+		}
+		result.Records = append(result.Records, record)
+	}
+	result.Records = append(result.Records, added...)
+
+	// Remove the records that are no longer members.
+	for _, record := range stale {
+		if _, err := c.client.call(ctx, "remove-record", schema.RecordDeleteParams{ID: record.ID, Domain: domain}, nil); err != nil {
+			errs = append(errs, err)
+			result.Records = append(result.Records, record)
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, fmt.Errorf("failed to replace %s records %s: %w", recordType, name, errors.Join(errs...))
+	}
+	return result, nil
+}
+
+// rollbackRRset removes the records added by a failed ReplaceRRset. It runs
+// even if ctx is done, since the add may have failed because of it, and
+// returns the errors of the removals that failed.
+func (c *RecordClient) rollbackRRset(ctx context.Context, domain string, added []schema.RecordResponse) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for _, record := range added {
+		params := schema.RecordDeleteParams{ID: record.ID, Domain: domain}
+		if _, err := c.client.call(ctx, "remove-record", params, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to roll back record %s: %w", record.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// newRecordServer returns a fake server with example.com holding the given records.
+func newRecordServer(t *testing.T, records ...schema.RecordResponse) *njallatest.Server {
+	t.Helper()
+	srv := njallatest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.Seed(njallatest.Fixture{
+		Domains: []schema.GetDomainRequestResponse{{Name: "example.com", Status: "active"}},
+		Records: map[string][]schema.RecordResponse{"example.com": records},
+	})
+	return srv
+}
+
+// contents returns the sorted contents of the records with the given name and type.
+func contents(records []schema.RecordResponse, name, recordType string) []string {
+	var values []string
+	for _, r := range records {
+		if r.Name == name && r.Type == recordType {
+			values = append(values, r.Content)
+		}
+	}
+	slices.Sort(values)
+	return values
+}
+
+func aRecord(content string) schema.RecordCreateParams {
+	return schema.RecordCreateParams{Content: content, TTL: 3600}
+}
+
+func TestReplaceRRset(t *testing.T) {
+	existing := []schema.RecordResponse{
+		{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{Name: "www", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+	}
+	tests := []struct {
+		name    string
+		members []schema.RecordCreateParams
+		want    []string
+	}{
+		{"unchanged", []schema.RecordCreateParams{aRecord("192.0.2.1"), aRecord("192.0.2.2")}, []string{"192.0.2.1", "192.0.2.2"}},
+		{"add and remove", []schema.RecordCreateParams{aRecord("192.0.2.2"), aRecord("192.0.2.3")}, []string{"192.0.2.2", "192.0.2.3"}},
+		{"duplicate members", []schema.RecordCreateParams{aRecord("192.0.2.3"), aRecord("192.0.2.3")}, []string{"192.0.2.3"}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newRecordServer(t, existing...)
+			c := srv.Client()
+
+			rrset, err := c.Record.ReplaceRRset(context.Background(), "example.com", "www", client.RecordTypeA, tt.members)
+			if err != nil {
+				t.Fatalf("ReplaceRRset() error = %v", err)
+			}
+			if got := contents(rrset.Records, "www", "A"); !slices.Equal(got, tt.want) {
+				t.Errorf("ReplaceRRset() records = %v, want %v", got, tt.want)
+			}
+			stored := srv.Records("example.com")
+			if got := contents(stored, "www", "A"); !slices.Equal(got, tt.want) {
+				t.Errorf("stored records = %v, want %v", got, tt.want)
+			}
+			if got := contents(stored, "www", "AAAA"); len(got) != 1 {
+				t.Errorf("AAAA records = %v, want them untouched", got)
+			}
+		})
+	}
+}
+
+func TestReplaceRRsetRollsBackAfterCancel(t *testing.T) {
+	srv := newRecordServer(t, schema.RecordResponse{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adds := 0
+	cancelOnSecondAdd := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+		if method == "add-record" {
+			if adds++; adds == 2 {
+				cancel()
+				return nil, ctx.Err()
+			}
+		}
+		return next(ctx, method, params, v)
+	}
+	c := srv.Client(client.WithInterceptors(cancelOnSecondAdd))
+
+	members := []schema.RecordCreateParams{aRecord("192.0.2.2"), aRecord("192.0.2.3")}
+	if _, err := c.Record.ReplaceRRset(ctx, "example.com", "www", client.RecordTypeA, members); err == nil {
+		t.Fatal("ReplaceRRset() error = nil, want an error")
+	}
+	if got := contents(srv.Records("example.com"), "www", "A"); !slices.Equal(got, []string{"192.0.2.1"}) {
+		t.Errorf("stored records = %v, want the RRset left as it was", got)
+	}
+}
+
+func TestReplaceRRsetReportsRollbackFailure(t *testing.T) {
+	srv := newRecordServer(t)
+	adds := 0
+	failSecondAdd := func(ctx context.Context, method string, params, v any, next client.Invoker) (any, error) {
+		if method == "add-record" {
+			if adds++; adds == 2 {
+				srv.InjectError("remove-record", client.APIError{Code: 500, Message: "remove failed"})
+				return nil, &client.APIError{Code: 500, Message: "add failed", Method: method}
+			}
+		}
+		return next(ctx, method, params, v)
+	}
+	c := srv.Client(client.WithInterceptors(failSecondAdd))
+
+	members := []schema.RecordCreateParams{aRecord("192.0.2.2"), aRecord("192.0.2.3")}
+	_, err := c.Record.ReplaceRRset(context.Background(), "example.com", "www", client.RecordTypeA, members)
+	if err == nil {
+		t.Fatal("ReplaceRRset() error = nil, want an error")
+	}
+	var errs []string
+	for _, want := range []string{"add failed", "remove failed"} {
+		if !strings.Contains(err.Error(), want) {
+			errs = append(errs, want)
+		}
+	}
+	if len(errs) > 0 {
+		t.Errorf("ReplaceRRset() error = %v, want it to mention %v", err, errs)
+	}
+}
@@ -1,5 +1,14 @@
 package schema
 
+type DNSSECResponse struct {
+	ID         string `json:"id"`
+	Algorithm  int    `json:"algorithm"`
+	Digest     string `json:"digest"`
+	DigestType int    `json:"digest_type"`
+	KeyTag     int    `json:"key_tag"`
+	PublicKey  string `json:"public_key"`
+}
+
 type DNSSECCreateParams struct {
 	Domain     string `json:"domain"`
 	Algorithm  int    `json:"algorithm"`
@@ -24,6 +33,12 @@ type DNSSECCreateRequest struct {
 }
 
 type DNSSECCreateRequestResponse struct {
+	ID         string `json:"id"`
+	Algorithm  int    `json:"algorithm"`
+	Digest     string `json:"digest"`
+	DigestType int    `json:"digest_type"`
+	KeyTag     int    `json:"key_tag"`
+	PublicKey  string `json:"public_key"`
 }
 
 type DNSSECListRequest struct {
@@ -32,11 +47,13 @@ type DNSSECListRequest struct {
 }
 
 type DNSSECListRequestResponse struct {
-	DNSSec []struct {
-	} `json:"dnssec"`
+	DNSSec []DNSSECResponse `json:"dnssec"`
 }
 
 type DNSSECDeleteRequest struct {
 	Method string             `json:"method"`
 	Params DNSSECDeleteParams `json:"params"`
 }
+
+type DNSSECDeleteRequestResponse struct {
+}
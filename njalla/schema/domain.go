@@ -7,6 +7,23 @@ type UpdateDomainParams struct {
 	Lock           bool   `json:"lock"`
 }
 
+// DomainPatchParams changes the settings of a domain with "edit-domain".
+// Nil fields are not sent.
+type DomainPatchParams struct {
+	Domain         string `json:"domain"`
+	MailForwarding *bool  `json:"mailforwarding,omitempty"`
+	DNSSEC         *bool  `json:"dnssec,omitempty"`
+	Lock           *bool  `json:"lock,omitempty"`
+	Autorenew      *bool  `json:"autorenew,omitempty"`
+}
+
+// SetNameserversParams sets the nameservers of a domain with "edit-domain".
+// An empty list switches the domain back to the Njalla nameservers.
+type SetNameserversParams struct {
+	Domain      string   `json:"domain"`
+	Nameservers []string `json:"nameservers"`
+}
+
 type FindDomainParams struct {
 	Query string `json:"query"`
 }
@@ -24,15 +41,16 @@ type UpdateDomainRequest struct {
 }
 
 type UpdateDomainRequestResponse struct {
-	Name           string `json:"name"`
-	Status         string `json:"status"`
-	Expiry         string `json:"expiry"`
-	Autorenew      bool   `json:"autorenew"`
-	Locked         bool   `json:"locked"`
-	Mailforwarding bool   `json:"mailforwarding"`
-	MaxNameservers int    `json:"maxnameservers"`
-	DNSSECType     string `json:"dnssec_type"`
-	MaxStaticPages int    `json:"maxstaticpages"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	Expiry         string   `json:"expiry"`
+	Autorenew      bool     `json:"autorenew"`
+	Locked         bool     `json:"locked"`
+	Mailforwarding bool     `json:"mailforwarding"`
+	MaxNameservers int      `json:"maxnameservers"`
+	Nameservers    []string `json:"nameservers"`
+	DNSSECType     string   `json:"dnssec_type"`
+	MaxStaticPages int      `json:"maxstaticpages"`
 }
 
 type FindDomainRequest struct {
@@ -44,6 +62,9 @@ type FindDomainRequestResponse struct {
 	Domains []FindDomainResponse `json:"domains"`
 }
 
+// FindDomainResponse is a domain returned by "find-domains". Status is
+// "available", "taken", "in progress" or "failed", and Price is the yearly
+// price in euros.
 type FindDomainResponse struct {
 	Price  int    `json:"price"`
 	Status string `json:"status"`
@@ -56,15 +77,16 @@ type GetDomainRequest struct {
 }
 
 type GetDomainRequestResponse struct {
-	Name           string `json:"name"`
-	Status         string `json:"status"`
-	Expiry         string `json:"expiry"`
-	Autorenew      bool   `json:"autorenew"`
-	Locked         bool   `json:"locked"`
-	Mailforwarding bool   `json:"mailforwarding"`
-	MaxNameservers int    `json:"max_nameservers"`
-	DNSSECType     string `json:"dnssec_type"`
-	MaxStaticPages int    `json:"max_static_pages"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	Expiry         string   `json:"expiry"`
+	Autorenew      bool     `json:"autorenew"`
+	Locked         bool     `json:"locked"`
+	Mailforwarding bool     `json:"mailforwarding"`
+	MaxNameservers int      `json:"max_nameservers"`
+	Nameservers    []string `json:"nameservers"`
+	DNSSECType     string   `json:"dnssec_type"`
+	MaxStaticPages int      `json:"max_static_pages"`
 }
 
 type ListDomainsRequest struct {
@@ -82,3 +104,13 @@ type ListDomainResponse struct {
 	Expiry    string `json:"expiry"`
 	Autorenew bool   `json:"autorenew"`
 }
+
+type RegisterDomainParams struct {
+	Domain string `json:"domain"`
+	Years  int    `json:"years"`
+}
+
+type RenewDomainParams struct {
+	Domain string `json:"domain"`
+	Years  int    `json:"years"`
+}
@@ -1,11 +1,84 @@
 package schema
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// RecordResponse is a DNS record as returned by the API. Fields that only
+// apply to some record types, such as Prio for MX or Port for SRV, are zero
+// for the other types. Fields the API returns that are not known to this
+// package are kept in Extra.
 type RecordResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Content string `json:"content"`
-	TTL     int    `json:"ttl"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Content      string `json:"content"`
+	TTL          int    `json:"ttl"`
+	Prio         int    `json:"prio,omitempty"`
+	Weight       int    `json:"weight,omitempty"`
+	Port         int    `json:"port,omitempty"`
+	Target       string `json:"target,omitempty"`
+	SSHAlgorithm int    `json:"ssh_algorithm,omitempty"`
+	SSHType      int    `json:"ssh_type,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// recordResponseFields lists the JSON fields decoded into named fields of RecordResponse.
+var recordResponseFields = []string{
+	"id", "name", "type", "content", "ttl", "prio", "weight", "port", "target", "ssh_algorithm", "ssh_type",
+}
+
+// isRecordResponseField reports whether a JSON key is decoded into a named field
+// of RecordResponse. Like encoding/json, it ignores case.
+func isRecordResponseField(key string) bool {
+	return slices.ContainsFunc(recordResponseFields, func(field string) bool {
+		return strings.EqualFold(field, key)
+	})
+}
+
+// UnmarshalJSON decodes a record, keeping unknown fields in Extra.
+func (r *RecordResponse) UnmarshalJSON(data []byte) error {
+	type plain RecordResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key, value := range fields {
+		if isRecordResponseField(key) {
+			continue
+		}
+		if r.Extra == nil {
+			r.Extra = make(map[string]json.RawMessage, len(fields))
+		}
+		r.Extra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON encodes a record, including the fields kept in Extra. Entries of
+// Extra named like a named field are left out, so a field is never encoded twice.
+func (r RecordResponse) MarshalJSON() ([]byte, error) {
+	type plain RecordResponse
+	data, err := json.Marshal(plain(r))
+	if err != nil || len(r.Extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range r.Extra {
+		if !isRecordResponseField(key) {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
 }
 
 type RecordCreateParams struct {
@@ -37,6 +110,24 @@ type RecordUpdateParams struct {
 	SSHType      int    `json:"ssh_type,omitempty"`
 }
 
+// RecordPatchParams describes a partial record update. Only the fields that are
+// set (non-nil) are sent, so zero values such as an MX priority of 0 or an
+// empty TXT content can be set explicitly.
+type RecordPatchParams struct {
+	ID           string  `json:"id"`
+	Domain       string  `json:"domain"`
+	Type         *string `json:"type,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Content      *string `json:"content,omitempty"`
+	TTL          *int    `json:"ttl,omitempty"`
+	Prio         *int    `json:"prio,omitempty"`
+	Weight       *int    `json:"weight,omitempty"`
+	Port         *int    `json:"port,omitempty"`
+	Target       *string `json:"target,omitempty"`
+	SSHAlgorithm *int    `json:"ssh_algorithm,omitempty"`
+	SSHType      *int    `json:"ssh_type,omitempty"`
+}
+
 type RecordListParams struct {
 	Domain string `json:"domain"`
 }
@@ -51,26 +142,14 @@ type RecordCreateRequest struct {
 	Params RecordCreateParams `json:"params"`
 }
 
-type RecordCreateRequestResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Content string `json:"content"`
-	TTL     int    `json:"ttl"`
-}
+type RecordCreateRequestResponse = RecordResponse
 
 type RecordUpdateRequest struct {
 	Method string             `json:"method"`
 	Params RecordUpdateParams `json:"params"`
 }
 
-type RecordUpdateRequestResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Content string `json:"content"`
-	TTL     int    `json:"ttl"`
-}
+type RecordUpdateRequestResponse = RecordResponse
 
 type RecordsListRequest struct {
 	Method string           `json:"method"`
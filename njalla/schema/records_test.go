@@ -0,0 +1,103 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestRecordResponseJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		want      schema.RecordResponse
+		wantExtra map[string]string
+	}{
+		{
+			name: "known fields only",
+			data: `{"id":"1","name":"@","type":"MX","content":"mx.example.com","ttl":3600,"prio":10}`,
+			want: schema.RecordResponse{ID: "1", Name: "@", Type: "MX", Content: "mx.example.com", TTL: 3600, Prio: 10},
+		},
+		{
+			name: "every typed field",
+			data: `{"id":"2","name":"_sip._tcp","type":"SRV","content":"","ttl":300,"prio":10,"weight":5,"port":5060,"target":"sip.example.com","ssh_algorithm":4,"ssh_type":2}`,
+			want: schema.RecordResponse{ID: "2", Name: "_sip._tcp", Type: "SRV", TTL: 300, Prio: 10, Weight: 5, Port: 5060, Target: "sip.example.com", SSHAlgorithm: 4, SSHType: 2},
+		},
+		{
+			name:      "unknown fields",
+			data:      `{"id":"3","name":"www","type":"A","content":"192.0.2.1","ttl":3600,"geo":{"region":"eu"},"proxied":true}`,
+			want:      schema.RecordResponse{ID: "3", Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			wantExtra: map[string]string{"geo": `{"region":"eu"}`, "proxied": "true"},
+		},
+		{
+			name: "known fields in another case",
+			data: `{"ID":"4","Name":"www","TYPE":"A","Content":"192.0.2.1","Ttl":3600}`,
+			want: schema.RecordResponse{ID: "4", Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got schema.RecordResponse
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			extra := got.Extra
+			got.Extra = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+			if len(extra) != len(tt.wantExtra) {
+				t.Errorf("Extra = %s, want %v", extra, tt.wantExtra)
+			}
+			for key, value := range tt.wantExtra {
+				if string(extra[key]) != value {
+					t.Errorf("Extra[%q] = %s, want %s", key, extra[key], value)
+				}
+			}
+
+			// Encoding and decoding again gives the same record.
+			got.Extra = extra
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var again schema.RecordResponse
+			if err := json.Unmarshal(data, &again); err != nil {
+				t.Fatalf("Unmarshal() of %s error = %v", data, err)
+			}
+			if !reflect.DeepEqual(again, got) {
+				t.Errorf("round trip = %+v, want %+v", again, got)
+			}
+		})
+	}
+}
+
+func TestRecordResponseMarshalSkipsTypedFieldsInExtra(t *testing.T) {
+	record := schema.RecordResponse{
+		ID: "1", Name: "@", Type: "MX", Content: "mx.example.com", TTL: 3600,
+		Extra: map[string]json.RawMessage{
+			"content": json.RawMessage(`"other.example.com"`),
+			"Prio":    json.RawMessage(`99`),
+			"geo":     json.RawMessage(`"eu"`),
+		},
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"id": `"1"`, "name": `"@"`, "type": `"MX"`, "content": `"mx.example.com"`, "ttl": "3600", "geo": `"eu"`}
+	if len(fields) != len(want) {
+		t.Errorf("Marshal() = %s, want the fields %v", data, want)
+	}
+	for key, value := range want {
+		if string(fields[key]) != value {
+			t.Errorf("field %q = %s, want %s", key, fields[key], value)
+		}
+	}
+}
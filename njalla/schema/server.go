@@ -0,0 +1,76 @@
+package schema
+
+type ServerResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Status    string   `json:"status"`
+	Type      string   `json:"type"`
+	OS        string   `json:"os"`
+	Expiry    string   `json:"expiry"`
+	Autorenew bool     `json:"autorenew"`
+	SSHKey    string   `json:"ssh_key"`
+	IPs       []string `json:"ips"`
+}
+
+type ServerListParams struct {
+}
+
+type ServerParams struct {
+	ID string `json:"id"`
+}
+
+type ServerCreateParams struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	OS     string `json:"os"`
+	SSHKey string `json:"ssh_key"`
+	Months int    `json:"months"`
+}
+
+type ServerResetParams struct {
+	ID     string `json:"id"`
+	OS     string `json:"os"`
+	SSHKey string `json:"ssh_key"`
+	Type   string `json:"type,omitempty"`
+}
+
+type ServerRenewParams struct {
+	ID     string `json:"id"`
+	Months int    `json:"months"`
+}
+
+type ServerListRequestResponse struct {
+	Servers []ServerResponse `json:"servers"`
+}
+
+type ServerGetRequestResponse = ServerResponse
+
+type ServerCreateRequestResponse struct {
+	ID   string `json:"id"`
+	Task string `json:"task"`
+}
+
+// ServerActionRequestResponse is returned by the methods that change the state
+// of a server. Task is set if the API runs the change asynchronously.
+type ServerActionRequestResponse struct {
+	Task string `json:"task"`
+}
+
+type ServerDeleteRequestResponse struct {
+}
+
+type ServerImageListRequestResponse struct {
+	Images []string `json:"images"`
+}
+
+type ServerTypeResponse struct {
+	Name  string `json:"name"`
+	CPU   int    `json:"cpu"`
+	RAM   int    `json:"ram"`
+	Disk  int    `json:"disk"`
+	Price int    `json:"price"`
+}
+
+type ServerTypeListRequestResponse struct {
+	Types []ServerTypeResponse `json:"types"`
+}
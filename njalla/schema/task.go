@@ -0,0 +1,16 @@
+package schema
+
+type CheckTaskParams struct {
+	ID string `json:"id"`
+}
+
+// TaskRequestResponse is returned by methods the API runs asynchronously, such
+// as "register-domain". The task can be followed with "check-task".
+type TaskRequestResponse struct {
+	Task string `json:"task"`
+}
+
+type CheckTaskRequestResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,44 @@
+package schema
+
+// TokenResponse is an API token. Empty restriction lists mean the token is
+// not restricted in that respect.
+type TokenResponse struct {
+	Key     string   `json:"key"`
+	Comment string   `json:"comment"`
+	From    []string `json:"from"`
+	ACL     []string `json:"acl"`
+	Domains []string `json:"domains"`
+}
+
+type TokenListParams struct {
+}
+
+type TokenCreateParams struct {
+	Comment string   `json:"comment,omitempty"`
+	From    []string `json:"from,omitempty"`
+	ACL     []string `json:"acl,omitempty"`
+	Domains []string `json:"domains,omitempty"`
+}
+
+type TokenUpdateParams struct {
+	Key     string   `json:"key"`
+	Comment string   `json:"comment"`
+	From    []string `json:"from"`
+	ACL     []string `json:"acl"`
+	Domains []string `json:"domains"`
+}
+
+type TokenDeleteParams struct {
+	Key string `json:"key"`
+}
+
+type TokenListRequestResponse struct {
+	Tokens []TokenResponse `json:"tokens"`
+}
+
+type TokenCreateRequestResponse = TokenResponse
+
+type TokenUpdateRequestResponse = TokenResponse
+
+type TokenDeleteRequestResponse struct {
+}
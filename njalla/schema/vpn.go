@@ -0,0 +1,74 @@
+package schema
+
+// VPNWireGuardResponse holds the WireGuard credentials of a VPN.
+type VPNWireGuardResponse struct {
+	PrivateKey   string   `json:"private_key"`
+	PublicKey    string   `json:"public_key"`
+	PresharedKey string   `json:"preshared_key"`
+	Address      []string `json:"address"`
+	DNS          []string `json:"dns"`
+	PeerKey      string   `json:"peer_public_key"`
+	Endpoint     string   `json:"endpoint"`
+	AllowedIPs   []string `json:"allowed_ips"`
+}
+
+// VPNOpenVPNResponse holds the OpenVPN credentials of a VPN.
+type VPNOpenVPNResponse struct {
+	Remote   string `json:"remote"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	CA       string `json:"ca"`
+	TLSCrypt string `json:"tls_crypt"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type VPNResponse struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Status    string                `json:"status"`
+	Expiry    string                `json:"expiry"`
+	Autorenew bool                  `json:"autorenew"`
+	WireGuard *VPNWireGuardResponse `json:"wireguard"`
+	OpenVPN   *VPNOpenVPNResponse   `json:"openvpn"`
+}
+
+type VPNListParams struct {
+}
+
+type VPNCreateParams struct {
+	Name      string `json:"name"`
+	Autorenew bool   `json:"autorenew"`
+	Months    int    `json:"months"`
+}
+
+type VPNUpdateParams struct {
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Autorenew *bool  `json:"autorenew,omitempty"`
+	PublicKey string `json:"publickey,omitempty"`
+}
+
+type VPNRenewParams struct {
+	ID     string `json:"id"`
+	Months int    `json:"months"`
+}
+
+type VPNDeleteParams struct {
+	ID string `json:"id"`
+}
+
+type VPNListRequestResponse struct {
+	VPNs []VPNResponse `json:"vpns"`
+}
+
+type VPNCreateRequestResponse = VPNResponse
+
+type VPNUpdateRequestResponse = VPNResponse
+
+type VPNRenewRequestResponse struct {
+	Task string `json:"task"`
+}
+
+type VPNDeleteRequestResponse struct {
+}
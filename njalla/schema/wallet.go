@@ -0,0 +1,35 @@
+package schema
+
+type GetBalanceParams struct {
+}
+
+type GetBalanceRequestResponse struct {
+	Balance int `json:"balance"`
+}
+
+type ListTransactionsParams struct {
+}
+
+type TransactionResponse struct {
+	ID        string `json:"id"`
+	Amount    int    `json:"amount"`
+	Status    string `json:"status"`
+	Completed string `json:"completed"`
+	PDF       string `json:"pdf"`
+	URL       string `json:"url"`
+}
+
+type ListTransactionsRequestResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+}
+
+type AddPaymentParams struct {
+	Amount int    `json:"amount"`
+	Via    string `json:"via"`
+}
+
+type AddPaymentRequestResponse struct {
+	Amount  int    `json:"amount"`
+	Address string `json:"address"`
+	URL     string `json:"url"`
+}
@@ -0,0 +1,270 @@
+package client
+
+import (
+	"context"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+type ServerClient struct {
+	client *Client
+}
+
+// ListServers retrieves the VPS servers of the account.
+// It sends a request to the "list-servers" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice of schema.ServerResponse containing the servers.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *ServerClient) ListServers(ctx context.Context) ([]schema.ServerResponse, error) {
+	const method string = "list-servers"
+	var responseScheme schema.ServerListRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.ServerListParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerListRequestResponse)
+	return response.Servers, nil
+}
+
+// GetServer retrieves information about a specific server.
+// It sends a request to the "get-server" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//
+// Returns:
+//   - A pointer to schema.ServerGetRequestResponse containing the server information.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *ServerClient) GetServer(ctx context.Context, id string) (*schema.ServerGetRequestResponse, error) {
+	const method string = "get-server"
+	var responseScheme schema.ServerGetRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.ServerParams{ID: id}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerGetRequestResponse)
+	return response, nil
+}
+
+// CreateServer orders a new server, which charges the account wallet.
+// It sends a request to the "add-server" API endpoint and parses the response.
+// The server is provisioned asynchronously; use WaitForTask with the returned
+// task ID to wait until it is ready.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - serverParams: The name, type, OS image, SSH public key and number of
+//     months of the server. Use ListServerTypes and ListServerImages for the
+//     available types and images.
+//
+// Returns:
+//   - A pointer to schema.ServerCreateRequestResponse containing the ID of the
+//     new server and of the provisioning task.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *ServerClient) CreateServer(ctx context.Context, serverParams schema.ServerCreateParams) (*schema.ServerCreateRequestResponse, error) {
+	const method string = "add-server"
+	var responseScheme schema.ServerCreateRequestResponse
+
+	resp, err := c.client.call(ctx, method, serverParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerCreateRequestResponse)
+	return response, nil
+}
+
+// StartServer boots a stopped server.
+// It first checks that the server exists, then sends a request to the
+// "start-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//
+// Returns:
+//   - A pointer to schema.ServerActionRequestResponse, with the task ID if the
+//     API runs the action asynchronously.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) StartServer(ctx context.Context, id string) (*schema.ServerActionRequestResponse, error) {
+	return c.serverAction(ctx, "start-server", id, schema.ServerParams{ID: id})
+}
+
+// StopServer shuts a running server down.
+// It first checks that the server exists, then sends a request to the
+// "stop-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//
+// Returns:
+//   - A pointer to schema.ServerActionRequestResponse, with the task ID if the
+//     API runs the action asynchronously.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) StopServer(ctx context.Context, id string) (*schema.ServerActionRequestResponse, error) {
+	return c.serverAction(ctx, "stop-server", id, schema.ServerParams{ID: id})
+}
+
+// RestartServer reboots a server.
+// It first checks that the server exists, then sends a request to the
+// "restart-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//
+// Returns:
+//   - A pointer to schema.ServerActionRequestResponse, with the task ID if the
+//     API runs the action asynchronously.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) RestartServer(ctx context.Context, id string) (*schema.ServerActionRequestResponse, error) {
+	return c.serverAction(ctx, "restart-server", id, schema.ServerParams{ID: id})
+}
+
+// ResetServer reinstalls a server from an OS image, erasing its disk.
+// It first checks that the server exists, then sends a request to the
+// "reset-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - resetParams: The ID of the server, the OS image and SSH public key to
+//     install, and optionally a new server type.
+//
+// Returns:
+//   - A pointer to schema.ServerActionRequestResponse, with the task ID if the
+//     API runs the reset asynchronously.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) ResetServer(ctx context.Context, resetParams schema.ServerResetParams) (*schema.ServerActionRequestResponse, error) {
+	return c.serverAction(ctx, "reset-server", resetParams.ID, resetParams)
+}
+
+// RenewServer extends the paid period of a server, which charges the account wallet.
+// It first checks that the server exists, then sends a request to the
+// "renew-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//   - months: The number of months to renew the server for. Values below 1 default to 1.
+//
+// Returns:
+//   - A pointer to schema.ServerActionRequestResponse, with the task ID if the
+//     API runs the renewal asynchronously.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) RenewServer(ctx context.Context, id string, months int) (*schema.ServerActionRequestResponse, error) {
+	return c.serverAction(ctx, "renew-server", id, schema.ServerRenewParams{ID: id, Months: max(months, 1)})
+}
+
+// serverAction checks that the server with the given ID exists and sends a
+// method that changes its state.
+func (c *ServerClient) serverAction(ctx context.Context, method string, id string, params any) (*schema.ServerActionRequestResponse, error) {
+	var responseScheme schema.ServerActionRequestResponse
+
+	if err := c.requireServer(ctx, id); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerActionRequestResponse)
+	return response, nil
+}
+
+// DeleteServer removes a server and erases its data. The remaining paid period
+// is not refunded.
+// It first checks that the server exists, then sends a request to the
+// "remove-server" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the server.
+//
+// Returns:
+//   - A pointer to schema.ServerDeleteRequestResponse containing the response from the server.
+//   - A *ResourceError wrapping ErrServerNotFound if the server does not
+//     exist, or an error if the request fails.
+func (c *ServerClient) DeleteServer(ctx context.Context, id string) (*schema.ServerDeleteRequestResponse, error) {
+	const method string = "remove-server"
+	var responseScheme schema.ServerDeleteRequestResponse
+
+	if err := c.requireServer(ctx, id); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.call(ctx, method, schema.ServerParams{ID: id}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerDeleteRequestResponse)
+	return response, nil
+}
+
+// requireServer returns a *ResourceError wrapping ErrServerNotFound if the
+// account has no server with the given ID.
+func (c *ServerClient) requireServer(ctx context.Context, id string) error {
+	servers, err := c.ListServers(ctx)
+	if err != nil {
+		return err
+	}
+	for _, server := range servers {
+		if server.ID == id {
+			return nil
+		}
+	}
+	return &ResourceError{Err: ErrServerNotFound, ID: id}
+}
+
+// ListServerImages retrieves the OS images servers can be installed from.
+// It sends a request to the "list-server-images" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice with the names of the images, e.g. "debian12".
+//   - An error if the request fails or the response cannot be parsed.
+func (c *ServerClient) ListServerImages(ctx context.Context) ([]string, error) {
+	const method string = "list-server-images"
+	var responseScheme schema.ServerImageListRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.ServerListParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerImageListRequestResponse)
+	return response.Images, nil
+}
+
+// ListServerTypes retrieves the server types that can be ordered, with their
+// resources and monthly price.
+// It sends a request to the "list-server-types" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice of schema.ServerTypeResponse containing the server types.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *ServerClient) ListServerTypes(ctx context.Context) ([]schema.ServerTypeResponse, error) {
+	const method string = "list-server-types"
+	var responseScheme schema.ServerTypeListRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.ServerListParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ServerTypeListRequestResponse)
+	return response.Types, nil
+}
@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/njallatest"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestServerActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		action     func(c *client.Client, id string) error
+		wantStatus string
+		wantGone   bool
+	}{
+		{"start", "start-server", func(c *client.Client, id string) error {
+			_, err := c.Server.StartServer(context.Background(), id)
+			return err
+		}, "running", false},
+		{"stop", "stop-server", func(c *client.Client, id string) error {
+			_, err := c.Server.StopServer(context.Background(), id)
+			return err
+		}, "stopped", false},
+		{"restart", "restart-server", func(c *client.Client, id string) error {
+			_, err := c.Server.RestartServer(context.Background(), id)
+			return err
+		}, "running", false},
+		{"reset", "reset-server", func(c *client.Client, id string) error {
+			_, err := c.Server.ResetServer(context.Background(), schema.ServerResetParams{ID: id, OS: "debian12", SSHKey: "ssh-ed25519 AAAA"})
+			return err
+		}, "running", false},
+		{"renew", "renew-server", func(c *client.Client, id string) error {
+			_, err := c.Server.RenewServer(context.Background(), id, 0)
+			return err
+		}, "stopped", false},
+		{"delete", "remove-server", func(c *client.Client, id string) error {
+			_, err := c.Server.DeleteServer(context.Background(), id)
+			return err
+		}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := njallatest.NewServer()
+			defer srv.Close()
+			srv.Seed(njallatest.Fixture{Servers: []schema.ServerResponse{{ID: "vps1", Name: "web", Status: "stopped", OS: "ubuntu2404"}}})
+			c := srv.Client()
+
+			if err := tt.action(c, "vps1"); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			servers := srv.Servers()
+			switch {
+			case tt.wantGone && len(servers) != 0:
+				t.Errorf("servers = %+v, want none", servers)
+			case !tt.wantGone && (len(servers) != 1 || servers[0].Status != tt.wantStatus):
+				t.Errorf("servers = %+v, want one with status %q", servers, tt.wantStatus)
+			}
+
+			t.Run("not found", func(t *testing.T) {
+				srv.ResetRequests()
+				err := tt.action(c, "vps2")
+				if !errors.Is(err, client.ErrServerNotFound) || !client.IsNotFound(err) {
+					t.Errorf("error = %v, want ErrServerNotFound", err)
+				}
+				var resourceErr *client.ResourceError
+				if !errors.As(err, &resourceErr) || resourceErr.ID != "vps2" {
+					t.Errorf("error = %#v, want a *ResourceError for vps2", err)
+				}
+				if got := len(srv.RequestsFor(tt.method)); got != 0 {
+					t.Errorf("%s requests = %d, want 0", tt.method, got)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Task statuses reported by "check-task". Statuses not listed in
+// taskDoneStatuses or taskFailedStatuses mean the task is still running.
+//
+// The API does not document the statuses a task can have, so the lists below
+// are a best guess from observed responses and common spellings. A task ending
+// in an unlisted status is polled until TaskPolling.Timeout or the context
+// stops WaitForTask.
+const (
+	TaskStatusCompleted string = "completed"
+	TaskStatusFailed    string = "failed"
+)
+
+var (
+	taskDoneStatuses   = []string{TaskStatusCompleted, "done", "success"}
+	taskFailedStatuses = []string{TaskStatusFailed, "error", "cancelled"}
+)
+
+// ErrTaskFailed is wrapped by the *TaskError returned when an asynchronous task
+// finishes unsuccessfully.
+var ErrTaskFailed = errors.New("task failed")
+
+// ErrTaskTimeout is returned by WaitForTask when a task has not finished within
+// TaskPolling.Timeout.
+var ErrTaskTimeout = errors.New("task did not finish in time")
+
+// TaskError is returned when an asynchronous task finishes unsuccessfully.
+//
+// Fields:
+//   - ID: The ID of the task.
+//   - Status: The final status reported by "check-task".
+type TaskError struct {
+	ID     string
+	Status string
+}
+
+// Error implements the error interface.
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%v: task %s finished with status %q", ErrTaskFailed, e.ID, e.Status)
+}
+
+// Unwrap returns ErrTaskFailed.
+func (e *TaskError) Unwrap() error {
+	return ErrTaskFailed
+}
+
+// TaskPolling controls how often WaitForTask checks an asynchronous task.
+//
+// Fields:
+//   - InitialInterval: The delay before the first check. It must be positive.
+//   - MaxInterval: The upper bound for the delay between checks. Zero means no bound.
+//   - Multiplier: The factor the delay grows by after each check. Values below 1 are treated as 1.
+//   - Timeout: How long to poll before giving up. Zero means polling only stops
+//     with the context.
+//
+// Polling stops when the task completes or fails, when Timeout has passed, or
+// when the context is done.
+type TaskPolling struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Timeout         time.Duration
+}
+
+// DefaultTaskPolling returns a TaskPolling starting at 1s and growing by half
+// up to 30s, giving up after 15 minutes.
+func DefaultTaskPolling() TaskPolling {
+	return TaskPolling{
+		InitialInterval: time.Second,
+		MaxInterval:     30 * time.Second,
+		Multiplier:      1.5,
+		Timeout:         15 * time.Minute,
+	}
+}
+
+// WithTaskPolling sets how often the client checks asynchronous tasks such as
+// domain registrations.
+//
+// Parameters:
+//   - polling: The polling intervals to use.
+//
+// Returns:
+//
+//	A ClientOption that applies the polling intervals to a Client instance.
+func WithTaskPolling(polling TaskPolling) ClientOption {
+	return func(client *Client) {
+		client.taskPolling = polling
+	}
+}
+
+// interval returns the delay before the check following the given number of checks.
+func (p TaskPolling) interval(checks int) time.Duration {
+	policy := RetryPolicy{InitialBackoff: p.InitialInterval, MaxBackoff: p.MaxInterval, Multiplier: p.Multiplier}
+	return policy.backoff(checks+1, nil)
+}
+
+// CheckTask retrieves the status of an asynchronous task.
+// It sends a request to the "check-task" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the task, as returned by e.g. "register-domain".
+//
+// Returns:
+//   - A pointer to schema.CheckTaskRequestResponse containing the task status.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *Client) CheckTask(ctx context.Context, id string) (*schema.CheckTaskRequestResponse, error) {
+	const method string = "check-task"
+	var responseScheme schema.CheckTaskRequestResponse
+
+	resp, err := c.call(ctx, method, schema.CheckTaskParams{ID: id}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.CheckTaskRequestResponse)
+	return response, nil
+}
+
+// WaitForTask polls an asynchronous task with "check-task" until it completes
+// or fails, waiting between checks as configured with WithTaskPolling.
+//
+// Parameters:
+//   - ctx: The context for the requests. Polling stops when it is done.
+//   - id: The ID of the task.
+//
+// Returns:
+//   - A pointer to schema.CheckTaskRequestResponse with the final task status,
+//     or with the last status seen if polling times out.
+//   - A *TaskError wrapping ErrTaskFailed if the task failed, an error wrapping
+//     ErrTaskTimeout if it has not finished within the polling timeout, the
+//     context error if the context is done first, an error if the polling
+//     interval is not positive, or the error of a failed check.
+func (c *Client) WaitForTask(ctx context.Context, id string) (*schema.CheckTaskRequestResponse, error) {
+	polling := c.taskPolling
+	if polling.InitialInterval <= 0 {
+		return nil, fmt.Errorf("task polling interval must be positive, got %s", polling.InitialInterval)
+	}
+	var deadline time.Time
+	if polling.Timeout > 0 {
+		deadline = time.Now().Add(polling.Timeout)
+	}
+
+	for checks := 0; ; checks++ {
+		// The last check is made when the timeout has passed.
+		wait := polling.interval(checks)
+		if !deadline.IsZero() {
+			wait = min(wait, max(time.Until(deadline), 0))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		task, err := c.CheckTask(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		status := strings.ToLower(task.Status)
+		switch {
+		case slices.Contains(taskDoneStatuses, status):
+			return task, nil
+		case slices.Contains(taskFailedStatuses, status):
+			return task, &TaskError{ID: id, Status: task.Status}
+		case !deadline.IsZero() && !time.Now().Before(deadline):
+			return task, fmt.Errorf("%w: task %s still has status %q after %s", ErrTaskTimeout, id, task.Status, polling.Timeout)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+func TestWaitForTask(t *testing.T) {
+	fast := client.TaskPolling{InitialInterval: time.Millisecond, Timeout: 50 * time.Millisecond}
+	tests := []struct {
+		name       string
+		polling    client.TaskPolling
+		statuses   []string
+		wantErr    error
+		wantChecks int32
+	}{
+		{"completes", fast, []string{"pending", "running", "Completed"}, nil, 3},
+		{"fails", fast, []string{"pending", "error"}, client.ErrTaskFailed, 2},
+		{"unknown status times out", fast, []string{"stuck"}, client.ErrTaskTimeout, -1},
+		{"zero interval", client.TaskPolling{Timeout: time.Second}, []string{"completed"}, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(checks.Add(1)) - 1
+				status := tt.statuses[min(n, len(tt.statuses)-1)]
+				fmt.Fprintf(w, `{"result":{"id":"t1","status":%q}}`, status)
+			}))
+			defer srv.Close()
+			c := client.NewClient(client.WithEndpoint(srv.URL), client.WithTaskPolling(tt.polling))
+
+			_, err := c.WaitForTask(context.Background(), "t1")
+			switch {
+			case tt.polling.InitialInterval <= 0:
+				if err == nil {
+					t.Fatal("WaitForTask() error = nil, want an error for the zero interval")
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Fatalf("WaitForTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := checks.Load(); tt.wantChecks >= 0 && got != tt.wantChecks {
+				t.Errorf("checks = %d, want %d", got, tt.wantChecks)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+type TokenClient struct {
+	client *Client
+}
+
+// tokenID identifies a token in errors without revealing the whole key.
+func tokenID(key string) string {
+	if len(key) <= 8 {
+		return "..."
+	}
+	return key[:4] + "..." + key[len(key)-4:]
+}
+
+// ListTokens retrieves the API tokens of the account and their restrictions.
+// It sends a request to the "list-tokens" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice of schema.TokenResponse containing the tokens.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *TokenClient) ListTokens(ctx context.Context) ([]schema.TokenResponse, error) {
+	const method string = "list-tokens"
+	var responseScheme schema.TokenListRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.TokenListParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.TokenListRequestResponse)
+	return response.Tokens, nil
+}
+
+// GetToken retrieves a specific API token by listing the tokens of the account.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - key: The key of the token.
+//
+// Returns:
+//   - A pointer to schema.TokenResponse containing the token.
+//   - A *ResourceError wrapping ErrTokenNotFound if the token does not exist,
+//     or an error if the request fails.
+func (c *TokenClient) GetToken(ctx context.Context, key string) (*schema.TokenResponse, error) {
+	tokens, err := c.ListTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, token := range tokens {
+		if token.Key == key {
+			return &tokens[i], nil
+		}
+	}
+	return nil, &ResourceError{Err: ErrTokenNotFound, ID: tokenID(key)}
+}
+
+// CreateToken creates a new API token.
+// It sends a request to the "add-token" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - tokenParams: The comment of the token and its restrictions: the IP
+//     addresses or networks it may be used from, the methods it may call, and
+//     the domains it may manage. Empty lists leave the token unrestricted.
+//
+// Returns:
+//   - A pointer to schema.TokenCreateRequestResponse containing the new token and its key.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *TokenClient) CreateToken(ctx context.Context, tokenParams schema.TokenCreateParams) (*schema.TokenCreateRequestResponse, error) {
+	const method string = "add-token"
+	var responseScheme schema.TokenCreateRequestResponse
+
+	resp, err := c.client.call(ctx, method, tokenParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.TokenCreateRequestResponse)
+	return response, nil
+}
+
+// UpdateToken replaces the comment and the restrictions of an API token.
+// It sends a request to the "edit-token" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - tokenParams: The key of the token, its new comment and its new restrictions.
+//     Empty lists remove the corresponding restriction.
+//
+// Returns:
+//   - A pointer to schema.TokenUpdateRequestResponse containing the updated token.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *TokenClient) UpdateToken(ctx context.Context, tokenParams schema.TokenUpdateParams) (*schema.TokenUpdateRequestResponse, error) {
+	const method string = "edit-token"
+	var responseScheme schema.TokenUpdateRequestResponse
+
+	resp, err := c.client.call(ctx, method, tokenParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.TokenUpdateRequestResponse)
+	return response, nil
+}
+
+// DeleteToken revokes an API token.
+// It sends a request to the "remove-token" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - key: The key of the token.
+//
+// Returns:
+//   - A pointer to schema.TokenDeleteRequestResponse containing the response from the server.
+//   - An error if the request fails.
+func (c *TokenClient) DeleteToken(ctx context.Context, key string) (*schema.TokenDeleteRequestResponse, error) {
+	const method string = "remove-token"
+	var responseScheme schema.TokenDeleteRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.TokenDeleteParams{Key: key}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.TokenDeleteRequestResponse)
+	return response, nil
+}
+
+// RotateToken replaces an API token with a new one carrying the same comment
+// and restrictions:
+//
+//  1. A new token is created.
+//  2. The new token is verified with a "list-domains" call sent with its key.
+//  3. The new token is handed to activate, which should store it wherever the
+//     old one is used.
+//  4. The old token is revoked.
+//
+// If verification or activate fails, the new token is revoked again, even if
+// ctx is done, and the old one is kept. The old token is revoked with the
+// client's own key, or with the new key if the client itself uses the old one.
+//
+// A Client never changes its key. After rotating the key of c itself, c keeps
+// sending the old, now revoked key, so further calls fail with an unauthorized
+// error. Create a new Client with the new key, e.g. in activate, or pass the
+// key with ContextWithAPIKey.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//   - oldKey: The key of the token to replace.
+//   - activate: Called with the verified new token before the old one is
+//     revoked. May be nil.
+//
+// Returns:
+//   - A pointer to schema.TokenResponse containing the new token. It is also
+//     returned if revoking the old token fails.
+//   - An error if a step fails.
+//
+// Example usage:
+//
+//	token, err := c.Token.RotateToken(ctx, oldKey, func(token *schema.TokenResponse) error {
+//	    return secrets.Put("njalla-api-key", token.Key)
+//	})
+func (c *TokenClient) RotateToken(ctx context.Context, oldKey string, activate func(*schema.TokenResponse) error) (*schema.TokenResponse, error) {
+	old, err := c.GetToken(ctx, oldKey)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.CreateToken(ctx, schema.TokenCreateParams{
+		Comment: old.Comment,
+		From:    old.From,
+		ACL:     old.ACL,
+		Domains: old.Domains,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+
+	if _, err := c.client.Domain.ListDomains(ContextWithAPIKey(ctx, token.Key)); err != nil {
+		return nil, c.abortRotation(ctx, token.Key, fmt.Errorf("failed to verify new token: %w", err))
+	}
+	if activate != nil {
+		if err := activate(token); err != nil {
+			return nil, c.abortRotation(ctx, token.Key, fmt.Errorf("failed to activate new token: %w", err))
+		}
+	}
+
+	revokeCtx := ctx
+	if oldKey == c.client.apiKey {
+		revokeCtx = ContextWithAPIKey(ctx, token.Key)
+	}
+	if _, err := c.DeleteToken(revokeCtx, oldKey); err != nil {
+		return token, fmt.Errorf("failed to revoke old token %s: %w", tokenID(oldKey), err)
+	}
+	return token, nil
+}
+
+// abortRotation revokes the new token of a failed rotation and returns err,
+// joined with the error of the revocation if it fails too. The token is revoked
+// even if ctx is done, so a cancelled rotation leaves no unused token behind.
+func (c *TokenClient) abortRotation(ctx context.Context, newKey string, err error) error {
+	if _, revokeErr := c.DeleteToken(context.WithoutCancel(ctx), newKey); revokeErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to revoke new token %s: %w", tokenID(newKey), revokeErr))
+	}
+	return err
+}
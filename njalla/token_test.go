@@ -0,0 +1,108 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+var (
+	oldTokenKey = strings.Repeat("a", 40)
+	newTokenKey = strings.Repeat("b", 40)
+)
+
+// tokenServer is a minimal token API that records the methods it receives.
+type tokenServer struct {
+	*httptest.Server
+	removeFails bool
+
+	mu      sync.Mutex
+	removed []string
+}
+
+func newTokenServer(t *testing.T) *tokenServer {
+	t.Helper()
+	s := &tokenServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string                   `json:"method"`
+			Params schema.TokenDeleteParams `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		switch req.Method {
+		case "list-tokens":
+			fmt.Fprintf(w, `{"result":{"tokens":[{"key":%q,"comment":"ci"}]}}`, oldTokenKey)
+		case "add-token":
+			fmt.Fprintf(w, `{"result":{"key":%q,"comment":"ci"}}`, newTokenKey)
+		case "list-domains":
+			fmt.Fprint(w, `{"result":{"domains":[]}}`)
+		case "remove-token":
+			s.mu.Lock()
+			s.removed = append(s.removed, req.Params.Key)
+			s.mu.Unlock()
+			if s.removeFails {
+				fmt.Fprint(w, `{"error":{"code":500,"message":"remove failed"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tokenServer) removedKeys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.removed...)
+}
+
+func TestRotateTokenRevokesNewTokenOnAbort(t *testing.T) {
+	tests := []struct {
+		name        string
+		removeFails bool
+		wantErrs    []string
+	}{
+		{"revocation succeeds", false, []string{"failed to activate new token"}},
+		{"revocation fails", true, []string{"failed to activate new token", "failed to revoke new token", "remove failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTokenServer(t)
+			srv.removeFails = tt.removeFails
+			c := client.NewClient(client.WithEndpoint(srv.URL), client.APIKey(strings.Repeat("c", 40)))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errActivate := errors.New("store unavailable")
+			_, err := c.Token.RotateToken(ctx, oldTokenKey, func(*schema.TokenResponse) error {
+				cancel()
+				return errActivate
+			})
+			if !errors.Is(err, errActivate) {
+				t.Fatalf("RotateToken() error = %v, want %v", err, errActivate)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("RotateToken() error = %v, want it to mention %q", err, want)
+				}
+			}
+			if got := srv.removedKeys(); len(got) != 1 || got[0] != newTokenKey {
+				t.Errorf("revoked tokens = %v, want only the new token", got)
+			}
+		})
+	}
+}
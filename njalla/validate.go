@@ -0,0 +1,312 @@
+package client
+
+import (
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Bounds checked by ValidateRecord.
+const (
+	MinRecordTTL    int = 60
+	MaxRecordTTL    int = 2147483647
+	MaxTXTLength    int = 65280
+	MaxTXTSegment   int = 255
+	maxHostnameLen  int = 253
+	maxLabelLen     int = 63
+	maxUint16       int = 65535
+	caaFlagCritical int = 128
+)
+
+// caaTags lists the CAA property tags registered with IANA.
+var caaTags = []string{"issue", "issuewild", "iodef", "issuemail", "issuevmc", "contactemail", "contactphone"}
+
+// sshfpFingerprintLengths maps SSHFP fingerprint types to the length of their hex digest.
+var sshfpFingerprintLengths = map[int]int{1: 40, 2: 64}
+
+// tlsaDigestLengths maps TLSA matching types to the length of their hex digest.
+var tlsaDigestLengths = map[int]int{1: 64, 2: 128}
+
+// FieldError describes a single invalid field of a record.
+type FieldError struct {
+	Field  string
+	Value  any
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidationError is returned when a record fails client-side validation.
+// It lists every invalid field and works with errors.As for each FieldError.
+type ValidationError struct {
+	Type   string
+	Name   string
+	Errors []*FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid %s record %q: %s", e.Type, e.Name, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the individual field errors.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// WithRecordValidation enables or disables the client-side validation that
+// CreateRecord and UpdateRecord run before sending a record. Validation is
+// enabled by default.
+//
+// Parameters:
+//   - enabled: Whether records are validated.
+//
+// Returns:
+//
+//	A ClientOption that applies the setting to a Client instance.
+func WithRecordValidation(enabled bool) ClientOption {
+	return func(client *Client) {
+		client.skipRecordValidation = !enabled
+	}
+}
+
+// recordValidator collects the field errors of a record.
+type recordValidator struct {
+	errs []*FieldError
+}
+
+func (v *recordValidator) fail(field string, value any, format string, args ...any) {
+	v.errs = append(v.errs, &FieldError{Field: field, Value: value, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *recordValidator) uint16(field string, value int) {
+	if value < 0 || value > maxUint16 {
+		v.fail(field, value, "must be between 0 and %d", maxUint16)
+	}
+}
+
+func (v *recordValidator) hostname(field string, value string, allowRoot bool) {
+	if allowRoot && value == "." {
+		return
+	}
+	if reason := hostnameError(value, false); reason != "" {
+		v.fail(field, value, "%s", reason)
+	}
+}
+
+func (v *recordValidator) hex(field string, value string, length int) {
+	if _, err := hex.DecodeString(value); err != nil || value == "" {
+		v.fail(field, value, "must be a hex string")
+		return
+	}
+	if length > 0 && len(value) != length {
+		v.fail(field, value, "must be %d hex characters long", length)
+	}
+}
+
+// hostnameError returns why name is not a valid host name, or an empty string
+// if it is. If wildcard is set, the first label may be "*".
+func hostnameError(name string, wildcard bool) string {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return "must not be empty"
+	}
+	if len(name) > maxHostnameLen {
+		return fmt.Sprintf("must be at most %d characters long", maxHostnameLen)
+	}
+	for i, label := range strings.Split(name, ".") {
+		if wildcard && i == 0 && label == "*" {
+			continue
+		}
+		if label == "" || len(label) > maxLabelLen {
+			return fmt.Sprintf("labels must be between 1 and %d characters long", maxLabelLen)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "labels must not start or end with a hyphen"
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return fmt.Sprintf("contains invalid character %q", r)
+			}
+		}
+	}
+	return ""
+}
+
+// ValidateRecord checks the params of a record for the syntax required by its
+// type, before it is sent to the API. It checks the record name, the TTL,
+// addresses, host names, priorities, ports and weights, CAA flags and tags,
+// TLSA usage, selector and matching type, SSHFP algorithm and fingerprint type,
+// hex encoded data and TXT length.
+//
+// The name "@" and an empty name both stand for the zone apex and are not
+// checked, so that callers that leave Name empty for the apex keep working.
+//
+// Parameters:
+//   - r: The params of the record.
+//
+// Returns:
+//   - nil if the record is valid, or a *ValidationError listing every invalid field.
+func ValidateRecord(r schema.RecordCreateParams) error {
+	v := &recordValidator{}
+
+	if r.Name != "@" && r.Name != "" {
+		if reason := hostnameError(r.Name, true); reason != "" {
+			v.fail("name", r.Name, "%s", reason)
+		}
+	}
+	if r.TTL != 0 && (r.TTL < MinRecordTTL || r.TTL > MaxRecordTTL) {
+		v.fail("ttl", r.TTL, "must be between %d and %d", MinRecordTTL, MaxRecordTTL)
+	}
+
+	switch RecordType(r.Type) {
+	case RecordTypeA:
+		if ip, err := netip.ParseAddr(r.Content); err != nil || !ip.Is4() {
+			v.fail("content", r.Content, "must be an IPv4 address")
+		}
+	case RecordTypeAAAA:
+		if ip, err := netip.ParseAddr(r.Content); err != nil || !ip.Is6() {
+			v.fail("content", r.Content, "must be an IPv6 address")
+		}
+	case RecordTypeANAME, RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		v.hostname("content", r.Content, false)
+	case RecordTypeMX:
+		v.uint16("prio", r.Prio)
+		v.hostname("content", r.Content, true)
+	case RecordTypeSRV:
+		v.uint16("prio", r.Prio)
+		v.uint16("weight", r.Weight)
+		v.uint16("port", r.Port)
+		v.hostname("target", r.Target, true)
+	case RecordTypeSVCB, RecordTypeHTTPS:
+		v.uint16("prio", r.Prio)
+		v.hostname("target", r.Target, true)
+	case RecordTypeSSHFP:
+		if !slices.Contains([]int{1, 2, 3, 4, 6}, r.SSHAlgorithm) {
+			v.fail("ssh_algorithm", r.SSHAlgorithm, "must be 1 (RSA), 2 (DSA), 3 (ECDSA), 4 (Ed25519) or 6 (Ed448)")
+		}
+		length, ok := sshfpFingerprintLengths[r.SSHType]
+		if !ok {
+			v.fail("ssh_type", r.SSHType, "must be 1 (SHA-1) or 2 (SHA-256)")
+		}
+		v.hex("content", r.Content, length)
+	case RecordTypeTLSA:
+		validateTLSA(v, r.Content)
+	case RecordTypeCAA:
+		validateCAA(v, r.Content)
+	case RecordTypeTXT:
+		validateTXT(v, r.Content)
+	case RecordTypeNAPTR:
+		if _, err := parseNAPTR(RecordHeader{}, r.Content); err != nil {
+			v.fail("content", r.Content, "must be `order preference \"flags\" \"service\" \"regexp\" replacement`")
+		}
+	case RecordTypeDynamic:
+	default:
+		v.fail("type", r.Type, "unknown record type")
+	}
+
+	if len(v.errs) > 0 {
+		return &ValidationError{Type: r.Type, Name: r.Name, Errors: v.errs}
+	}
+	return nil
+}
+
+// validateTLSA checks the content of a TLSA record.
+func validateTLSA(v *recordValidator, content string) {
+	fields := strings.Fields(content)
+	if len(fields) != 4 {
+		v.fail("content", content, "must be `usage selector matching-type data`")
+		return
+	}
+	limits := []struct {
+		field string
+		max   int
+	}{{"usage", 3}, {"selector", 1}, {"matching_type", 2}}
+	var matchingType int
+	for i, limit := range limits {
+		value, err := strconv.Atoi(fields[i])
+		if err != nil || value < 0 || value > limit.max {
+			v.fail(limit.field, fields[i], "must be between 0 and %d", limit.max)
+		}
+		if limit.field == "matching_type" {
+			matchingType = value
+		}
+	}
+	v.hex("data", fields[3], tlsaDigestLengths[matchingType])
+}
+
+// validateCAA checks the content of a CAA record.
+func validateCAA(v *recordValidator, content string) {
+	fields, err := splitQuoted(content)
+	if err != nil || len(fields) != 3 {
+		v.fail("content", content, "must be `flags tag \"value\"`")
+		return
+	}
+	flags, err := strconv.Atoi(fields[0])
+	if err != nil || (flags != 0 && flags != caaFlagCritical) {
+		v.fail("flags", fields[0], "must be 0 or %d", caaFlagCritical)
+	}
+	if !slices.Contains(caaTags, strings.ToLower(fields[1])) {
+		v.fail("tag", fields[1], "must be one of %s", strings.Join(caaTags, ", "))
+	}
+	if strings.EqualFold(fields[1], "iodef") && fields[2] == "" {
+		v.fail("value", fields[2], "must be a mailto: or https: URL")
+	}
+}
+
+// validateTXT checks the length of the content of a TXT record. Content made
+// of quoted strings is checked string by string.
+func validateTXT(v *recordValidator, content string) {
+	if len(content) > MaxTXTLength {
+		v.fail("content", len(content), "must be at most %d characters long", MaxTXTLength)
+		return
+	}
+	if !strings.HasPrefix(content, `"`) {
+		return
+	}
+	segments, err := splitQuoted(content)
+	if err != nil {
+		v.fail("content", content, "has unbalanced quotes")
+		return
+	}
+	for i, segment := range segments {
+		if len(segment) > MaxTXTSegment {
+			v.fail(fmt.Sprintf("content[%d]", i), len(segment), "quoted strings must be at most %d characters long", MaxTXTSegment)
+		}
+	}
+}
+
+// updateAsCreateParams returns the params of the record that results from
+// applying an update to an existing record, for validation.
+func updateAsCreateParams(r schema.RecordUpdateParams, existing schema.RecordResponse) schema.RecordCreateParams {
+	return schema.RecordCreateParams{
+		Domain:       r.Domain,
+		Type:         cmp.Or(r.Type, existing.Type),
+		Name:         cmp.Or(r.Name, existing.Name),
+		Content:      cmp.Or(r.Content, existing.Content),
+		TTL:          cmp.Or(r.TTL, existing.TTL),
+		Prio:         cmp.Or(r.Prio, existing.Prio),
+		Weight:       cmp.Or(r.Weight, existing.Weight),
+		Port:         cmp.Or(r.Port, existing.Port),
+		Target:       cmp.Or(r.Target, existing.Target),
+		SSHAlgorithm: cmp.Or(r.SSHAlgorithm, existing.SSHAlgorithm),
+		SSHType:      cmp.Or(r.SSHType, existing.SSHType),
+	}
+}
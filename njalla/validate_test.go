@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func TestValidateRecord(t *testing.T) {
+	sha256 := strings.Repeat("ab", 32)
+	tests := []struct {
+		name       string
+		record     schema.RecordCreateParams
+		wantFields []string
+	}{
+		{"A", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.1"}, nil},
+		{"A with IPv6", schema.RecordCreateParams{Type: "A", Name: "www", Content: "2001:db8::1"}, []string{"content"}},
+		{"AAAA", schema.RecordCreateParams{Type: "AAAA", Name: "www", Content: "2001:db8::1"}, nil},
+		{"AAAA with IPv4-mapped address", schema.RecordCreateParams{Type: "AAAA", Name: "www", Content: "::ffff:192.0.2.1"}, nil},
+		{"AAAA with IPv4", schema.RecordCreateParams{Type: "AAAA", Name: "www", Content: "192.0.2.1"}, []string{"content"}},
+		{"apex", schema.RecordCreateParams{Type: "A", Name: "@", Content: "192.0.2.1"}, nil},
+		{"empty name for the apex", schema.RecordCreateParams{Type: "A", Name: "", Content: "192.0.2.1"}, nil},
+		{"wildcard", schema.RecordCreateParams{Type: "A", Name: "*.dev", Content: "192.0.2.1"}, nil},
+		{"bad name", schema.RecordCreateParams{Type: "A", Name: "bad_label-.", Content: "192.0.2.1"}, []string{"name"}},
+		{"TTL too low", schema.RecordCreateParams{Type: "A", Name: "www", Content: "192.0.2.1", TTL: 10}, []string{"ttl"}},
+		{"CNAME", schema.RecordCreateParams{Type: "CNAME", Name: "www", Content: "example.net"}, nil},
+		{"MX", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mail.example.com", Prio: 10}, nil},
+		{"MX priority out of range", schema.RecordCreateParams{Type: "MX", Name: "@", Content: "mail.example.com", Prio: 70000}, []string{"prio"}},
+		{"SRV", schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Prio: 10, Weight: 5, Port: 5060, Target: "sip.example.com"}, nil},
+		{"SRV with bad port", schema.RecordCreateParams{Type: "SRV", Name: "_sip._tcp", Port: -1, Target: "sip.example.com"}, []string{"port"}},
+		{"SSHFP", schema.RecordCreateParams{Type: "SSHFP", Name: "host", SSHAlgorithm: 4, SSHType: 2, Content: sha256}, nil},
+		{"SSHFP with bad algorithm", schema.RecordCreateParams{Type: "SSHFP", Name: "host", SSHAlgorithm: 5, SSHType: 2, Content: sha256}, []string{"ssh_algorithm"}},
+		{"TLSA", schema.RecordCreateParams{Type: "TLSA", Name: "_443._tcp", Content: "3 1 1 " + sha256}, nil},
+		{"TLSA with bad usage", schema.RecordCreateParams{Type: "TLSA", Name: "_443._tcp", Content: "4 1 1 " + sha256}, []string{"usage"}},
+		{"CAA", schema.RecordCreateParams{Type: "CAA", Name: "@", Content: `0 issue "letsencrypt.org"`}, nil},
+		{"CAA with unknown tag", schema.RecordCreateParams{Type: "CAA", Name: "@", Content: `0 issuer "letsencrypt.org"`}, []string{"tag"}},
+		{"TXT", schema.RecordCreateParams{Type: "TXT", Name: "@", Content: "v=spf1 -all"}, nil},
+		{"TXT too long", schema.RecordCreateParams{Type: "TXT", Name: "@", Content: strings.Repeat("x", client.MaxTXTLength+1)}, []string{"content"}},
+		{"unknown type", schema.RecordCreateParams{Type: "XYZ", Name: "www"}, []string{"type"}},
+		{"several invalid fields", schema.RecordCreateParams{Type: "A", Name: "www", Content: "nope", TTL: 1}, []string{"ttl", "content"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.ValidateRecord(tt.record)
+			var fields []string
+			var validationErr *client.ValidationError
+			if errors.As(err, &validationErr) {
+				for _, fieldErr := range validationErr.Errors {
+					fields = append(fields, fieldErr.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateRecord() error = %v, want a *ValidationError", err)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("ValidateRecord() invalid fields = %v, want %v (error: %v)", fields, tt.wantFields, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+type VPNClient struct {
+	client *Client
+}
+
+// ListVPNs retrieves the VPNs of the account, including their credentials.
+// It sends a request to the "list-vpns" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice of schema.VPNResponse containing the VPNs.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *VPNClient) ListVPNs(ctx context.Context) ([]schema.VPNResponse, error) {
+	const method string = "list-vpns"
+	var responseScheme schema.VPNListRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.VPNListParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.VPNListRequestResponse)
+	return response.VPNs, nil
+}
+
+// GetVPN retrieves a specific VPN by listing the VPNs of the account.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the VPN.
+//
+// Returns:
+//   - A pointer to schema.VPNResponse containing the VPN.
+//   - A *ResourceError wrapping ErrVPNNotFound if the VPN does not exist, or an
+//     error if the request fails.
+func (c *VPNClient) GetVPN(ctx context.Context, id string) (*schema.VPNResponse, error) {
+	vpns, err := c.ListVPNs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, vpn := range vpns {
+		if vpn.ID == id {
+			return &vpns[i], nil
+		}
+	}
+	return nil, &ResourceError{Err: ErrVPNNotFound, ID: id}
+}
+
+// CreateVPN orders a new VPN, which charges the account wallet.
+// It sends a request to the "add-vpn" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - vpnParams: The name of the VPN, whether it renews automatically, and the
+//     number of months to order it for.
+//
+// Returns:
+//   - A pointer to schema.VPNCreateRequestResponse containing the new VPN and its credentials.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *VPNClient) CreateVPN(ctx context.Context, vpnParams schema.VPNCreateParams) (*schema.VPNCreateRequestResponse, error) {
+	const method string = "add-vpn"
+	var responseScheme schema.VPNCreateRequestResponse
+
+	vpnParams.Months = max(vpnParams.Months, 1)
+	resp, err := c.client.call(ctx, method, vpnParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.VPNCreateRequestResponse)
+	return response, nil
+}
+
+// UpdateVPN changes the name, the autorenew setting or the WireGuard public key
+// of a VPN. Fields left empty or nil are not changed.
+// It sends a request to the "edit-vpn" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - vpnParams: The ID of the VPN and the fields to change.
+//
+// Returns:
+//   - A pointer to schema.VPNUpdateRequestResponse containing the updated VPN.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *VPNClient) UpdateVPN(ctx context.Context, vpnParams schema.VPNUpdateParams) (*schema.VPNUpdateRequestResponse, error) {
+	const method string = "edit-vpn"
+	var responseScheme schema.VPNUpdateRequestResponse
+
+	resp, err := c.client.call(ctx, method, vpnParams, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.VPNUpdateRequestResponse)
+	return response, nil
+}
+
+// RenewVPN extends the paid period of a VPN, which charges the account wallet.
+// It sends a request to the "renew-vpn" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the VPN.
+//   - months: The number of months to renew the VPN for. Values below 1 default to 1.
+//
+// Returns:
+//   - A pointer to schema.VPNRenewRequestResponse, with the task ID if the API
+//     runs the renewal asynchronously.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *VPNClient) RenewVPN(ctx context.Context, id string, months int) (*schema.VPNRenewRequestResponse, error) {
+	const method string = "renew-vpn"
+	var responseScheme schema.VPNRenewRequestResponse
+
+	params := schema.VPNRenewParams{ID: id, Months: max(months, 1)}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.VPNRenewRequestResponse)
+	return response, nil
+}
+
+// DeleteVPN removes a VPN and revokes its credentials.
+// It sends a request to the "remove-vpn" API endpoint.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - id: The ID of the VPN.
+//
+// Returns:
+//   - A pointer to schema.VPNDeleteRequestResponse containing the response from the server.
+//   - An error if the request fails.
+func (c *VPNClient) DeleteVPN(ctx context.Context, id string) (*schema.VPNDeleteRequestResponse, error) {
+	const method string = "remove-vpn"
+	var responseScheme schema.VPNDeleteRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.VPNDeleteParams{ID: id}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.VPNDeleteRequestResponse)
+	return response, nil
+}
+
+// ProvisionVPN orders a new VPN and writes its configuration file to dir, so a
+// new user can be onboarded with one call.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - vpnParams: The VPN to order, as for CreateVPN.
+//   - format: The configuration format to write.
+//   - dir: The directory to write the configuration file to.
+//
+// Returns:
+//   - A pointer to schema.VPNResponse containing the new VPN.
+//   - The path of the configuration file.
+//   - An error if the request fails, or if the file cannot be written or
+//     already exists. The VPN is returned even if writing the file fails.
+func (c *VPNClient) ProvisionVPN(ctx context.Context, vpnParams schema.VPNCreateParams, format VPNConfigFormat, dir string) (*schema.VPNResponse, string, error) {
+	vpn, err := c.CreateVPN(ctx, vpnParams)
+	if err != nil {
+		return nil, "", err
+	}
+	path, err := WriteVPNConfig(*vpn, format, dir)
+	if err != nil {
+		return vpn, "", err
+	}
+	return vpn, path, nil
+}
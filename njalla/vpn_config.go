@@ -0,0 +1,269 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// VPNConfigFormat is the format of a VPN configuration file.
+type VPNConfigFormat string
+
+const (
+	// VPNWireGuard is a WireGuard configuration, written as "<name>.conf".
+	VPNWireGuard VPNConfigFormat = "wireguard"
+	// VPNOpenVPN is an OpenVPN profile with inline certificates and
+	// credentials, written as "<name>.ovpn".
+	VPNOpenVPN VPNConfigFormat = "openvpn"
+)
+
+// vpnConfigExtensions maps configuration formats to file extensions.
+var vpnConfigExtensions = map[VPNConfigFormat]string{
+	VPNWireGuard: ".conf",
+	VPNOpenVPN:   ".ovpn",
+}
+
+// ErrVPNCredentialsMissing is returned when a VPN has no credentials for the
+// requested configuration format.
+var ErrVPNCredentialsMissing = errors.New("VPN has no credentials for the requested format")
+
+// ErrVPNCredentialsInvalid is returned when the credentials of a VPN cannot be
+// rendered safely, e.g. because a value spans several lines and would add
+// directives to the configuration, or a required value is empty.
+var ErrVPNCredentialsInvalid = errors.New("VPN credentials cannot be rendered safely")
+
+var wireGuardTemplate = template.Must(template.New("wireguard").Funcs(template.FuncMap{"join": strings.Join}).Parse(
+	`[Interface]
+PrivateKey = {{.PrivateKey}}
+{{- if .Address}}
+Address = {{join .Address ", "}}
+{{- end}}
+{{- if .DNS}}
+DNS = {{join .DNS ", "}}
+{{- end}}
+
+[Peer]
+PublicKey = {{.PeerKey}}
+{{- if .PresharedKey}}
+PresharedKey = {{.PresharedKey}}
+{{- end}}
+Endpoint = {{.Endpoint}}
+AllowedIPs = {{if .AllowedIPs}}{{join .AllowedIPs ", "}}{{else}}0.0.0.0/0, ::/0{{end}}
+`))
+
+var openVPNTemplate = template.Must(template.New("openvpn").Parse(
+	`client
+dev tun
+proto {{or .Protocol "udp"}}
+remote {{.Remote}} {{or .Port 1194}}
+resolv-retry infinite
+nobind
+persist-key
+persist-tun
+remote-cert-tls server
+verb 3
+{{- if .CA}}
+<ca>
+{{.CA}}
+</ca>
+{{- end}}
+{{- if .TLSCrypt}}
+<tls-crypt>
+{{.TLSCrypt}}
+</tls-crypt>
+{{- end}}
+{{- if .Username}}
+<auth-user-pass>
+{{.Username}}
+{{.Password}}
+</auth-user-pass>
+{{- end}}
+`))
+
+// RenderVPNConfig renders the credentials of a VPN as a WireGuard configuration
+// or an OpenVPN profile.
+//
+// Parameters:
+//   - vpn: The VPN, as returned by CreateVPN or ListVPNs.
+//   - format: The configuration format.
+//
+// The values returned by the API are checked before they are rendered: keys,
+// addresses, the endpoint and the protocol must be single words, the OpenVPN
+// credentials single lines, and the inline certificates must not close their
+// block early. The WireGuard endpoint, keys and the OpenVPN remote are required.
+//
+// Returns:
+//   - The configuration file contents. They contain secrets and should be
+//     stored with restrictive permissions.
+//   - ErrVPNCredentialsMissing if the VPN has no credentials for the format,
+//     an error wrapping ErrVPNCredentialsInvalid if they cannot be rendered
+//     safely, or an error if the format is unknown.
+func RenderVPNConfig(vpn schema.VPNResponse, format VPNConfigFormat) ([]byte, error) {
+	var (
+		tmpl    *template.Template
+		data    any
+		checker vpnConfigChecker
+	)
+	switch format {
+	case VPNWireGuard:
+		wg := vpn.WireGuard
+		if wg == nil {
+			return nil, fmt.Errorf("%w: %s %s", ErrVPNCredentialsMissing, format, vpn.ID)
+		}
+		checker.word("private_key", wg.PrivateKey, true)
+		checker.word("peer_public_key", wg.PeerKey, true)
+		checker.word("preshared_key", wg.PresharedKey, false)
+		checker.word("endpoint", wg.Endpoint, true)
+		checker.words("address", wg.Address)
+		checker.words("dns", wg.DNS)
+		checker.words("allowed_ips", wg.AllowedIPs)
+		tmpl, data = wireGuardTemplate, wg
+	case VPNOpenVPN:
+		ovpn := vpn.OpenVPN
+		if ovpn == nil {
+			return nil, fmt.Errorf("%w: %s %s", ErrVPNCredentialsMissing, format, vpn.ID)
+		}
+		checker.word("remote", ovpn.Remote, true)
+		checker.word("protocol", ovpn.Protocol, false)
+		checker.block("ca", ovpn.CA)
+		checker.block("tls_crypt", ovpn.TLSCrypt)
+		checker.line("username", ovpn.Username)
+		checker.line("password", ovpn.Password)
+		tmpl, data = openVPNTemplate, ovpn
+	default:
+		return nil, fmt.Errorf("unknown VPN config format %q", format)
+	}
+	if len(checker.problems) > 0 {
+		return nil, fmt.Errorf("%w: %s %s: %s", ErrVPNCredentialsInvalid, format, vpn.ID, strings.Join(checker.problems, "; "))
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s config: %w", format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// vpnConfigChecker collects the values of a VPN that cannot be placed in a
+// configuration file as they are. Values are never included in the problems,
+// since they may be secrets.
+type vpnConfigChecker struct {
+	problems []string
+}
+
+// word checks a value that must be a single word, such as a key or an address.
+func (c *vpnConfigChecker) word(field, value string, required bool) {
+	switch {
+	case value == "" && required:
+		c.problems = append(c.problems, field+" is empty")
+	case strings.ContainsFunc(value, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }):
+		c.problems = append(c.problems, field+" contains whitespace or control characters")
+	}
+}
+
+// words checks a list of values that must each be a single word.
+func (c *vpnConfigChecker) words(field string, values []string) {
+	for _, value := range values {
+		c.word(field, value, true)
+	}
+}
+
+// line checks a value that must fit on a single line.
+func (c *vpnConfigChecker) line(field, value string) {
+	if strings.ContainsFunc(value, unicode.IsControl) {
+		c.problems = append(c.problems, field+" contains control characters")
+	}
+}
+
+// block checks an inline PEM block, which may span several lines but must not
+// end the surrounding <tag> block.
+func (c *vpnConfigChecker) block(field, value string) {
+	if strings.Contains(value, "</") {
+		c.problems = append(c.problems, field+" contains a closing tag")
+	}
+	if strings.ContainsFunc(value, func(r rune) bool { return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' }) {
+		c.problems = append(c.problems, field+" contains control characters")
+	}
+}
+
+// unsafeFileChars matches the characters replaced in VPN config file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// vpnConfigName returns the file name of the configuration of a VPN.
+// WireGuard interface names are limited to 15 characters, so the name is
+// shortened for WireGuard configurations.
+func vpnConfigName(vpn schema.VPNResponse, format VPNConfigFormat) string {
+	sanitize := func(name string) string {
+		return strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-")
+	}
+	name := sanitize(vpn.Name)
+	if name == "" {
+		name = strings.TrimSuffix("njalla-"+sanitize(vpn.ID), "-")
+	}
+	if format == VPNWireGuard && len(name) > 15 {
+		name = strings.Trim(name[:15], "-")
+	}
+	return name + vpnConfigExtensions[format]
+}
+
+// WriteVPNConfig renders the configuration of a VPN and writes it to dir. The
+// file is named after the VPN and is readable by its owner only (mode 0600).
+//
+// An existing file is never replaced, since it may hold the credentials of
+// another VPN whose name shortens to the same file name. Remove the old file
+// first to replace it.
+//
+// Parameters:
+//   - vpn: The VPN, as returned by CreateVPN or ListVPNs.
+//   - format: The configuration format.
+//   - dir: The directory to write the file to. It must exist.
+//
+// Returns:
+//   - The path of the written file.
+//   - An error wrapping fs.ErrExist if the file already exists, or an error
+//     if the configuration cannot be rendered or written.
+//
+// Example usage:
+//
+//	vpn, err := c.VPN.CreateVPN(ctx, schema.VPNCreateParams{Name: "alice"})
+//	if err != nil {
+//	    return err
+//	}
+//	path, err := client.WriteVPNConfig(*vpn, client.VPNWireGuard, "/etc/wireguard")
+func WriteVPNConfig(vpn schema.VPNResponse, format VPNConfigFormat, dir string) (string, error) {
+	config, err := RenderVPNConfig(vpn, format)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, vpnConfigName(vpn, format))
+
+	// os.CreateTemp creates the file with mode 0600.
+	f, err := os.CreateTemp(dir, ".njalla-vpn-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(config); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(f.Name(), 0o600); err != nil {
+		return "", err
+	}
+	// os.Link fails if path exists, unlike os.Rename.
+	if err := os.Link(f.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
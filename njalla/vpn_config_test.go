@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+func testVPN(id, name string) schema.VPNResponse {
+	return schema.VPNResponse{
+		ID:   id,
+		Name: name,
+		WireGuard: &schema.VPNWireGuardResponse{
+			PrivateKey: "private-" + id,
+			Address:    []string{"10.0.0.2/32"},
+			PeerKey:    "peer",
+			Endpoint:   "vpn.example.net:51820",
+		},
+		OpenVPN: &schema.VPNOpenVPNResponse{Remote: "vpn.example.net", Username: "user", Password: "secret"},
+	}
+}
+
+func TestWriteVPNConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		vpn      schema.VPNResponse
+		format   client.VPNConfigFormat
+		wantFile string
+		wantText string
+	}{
+		{"wireguard", testVPN("1", "alice"), client.VPNWireGuard, "alice.conf", "PrivateKey = private-1"},
+		{"openvpn", testVPN("1", "alice"), client.VPNOpenVPN, "alice.ovpn", "<auth-user-pass>\nuser\nsecret"},
+		{"long wireguard name", testVPN("1", "contractor-alice"), client.VPNWireGuard, "contractor-alic.conf", "AllowedIPs = 0.0.0.0/0, ::/0"},
+		{"unsafe name", testVPN("1", "../Alice's laptop"), client.VPNOpenVPN, "Alice-s-laptop.ovpn", "remote vpn.example.net 1194"},
+		{"unsafe ID fallback", testVPN("../../x", ""), client.VPNOpenVPN, "njalla-x.ovpn", "client"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path, err := client.WriteVPNConfig(tt.vpn, tt.format, dir)
+			if err != nil {
+				t.Fatalf("WriteVPNConfig() error = %v", err)
+			}
+			if path != filepath.Join(dir, tt.wantFile) {
+				t.Errorf("WriteVPNConfig() path = %s, want %s", path, filepath.Join(dir, tt.wantFile))
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0o600 {
+				t.Errorf("file mode = %v, want 0600", perm)
+			}
+			data, _ := os.ReadFile(path)
+			if !strings.Contains(string(data), tt.wantText) {
+				t.Errorf("config = %q, want it to contain %q", data, tt.wantText)
+			}
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Errorf("files in dir = %d, want 1", len(entries))
+			}
+		})
+	}
+}
+
+func TestWriteVPNConfigDoesNotOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	path, err := client.WriteVPNConfig(testVPN("1", "contractor-alice"), client.VPNWireGuard, dir)
+	if err != nil {
+		t.Fatalf("WriteVPNConfig() error = %v", err)
+	}
+	_, err = client.WriteVPNConfig(testVPN("2", "contractor-alicia"), client.VPNWireGuard, dir)
+	if !errors.Is(err, fs.ErrExist) {
+		t.Fatalf("WriteVPNConfig() error = %v, want fs.ErrExist", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "private-1") {
+		t.Errorf("config was overwritten: %q", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("files in dir = %d, want 1", len(entries))
+	}
+}
+
+func TestRenderVPNConfigMissingCredentials(t *testing.T) {
+	_, err := client.RenderVPNConfig(schema.VPNResponse{ID: "1"}, client.VPNWireGuard)
+	if !errors.Is(err, client.ErrVPNCredentialsMissing) {
+		t.Errorf("RenderVPNConfig() error = %v, want ErrVPNCredentialsMissing", err)
+	}
+}
+
+func TestRenderVPNConfigRejectsUnsafeValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		format client.VPNConfigFormat
+		modify func(*schema.VPNResponse)
+	}{
+		{"newline in private key", client.VPNWireGuard, func(v *schema.VPNResponse) {
+			v.WireGuard.PrivateKey = "key\n[Peer]\nPublicKey = attacker"
+		}},
+		{"carriage return in peer key", client.VPNWireGuard, func(v *schema.VPNResponse) { v.WireGuard.PeerKey = "peer\rPostUp = sh" }},
+		{"newline in preshared key", client.VPNWireGuard, func(v *schema.VPNResponse) { v.WireGuard.PresharedKey = "psk\nPostUp = sh" }},
+		{"newline in endpoint", client.VPNWireGuard, func(v *schema.VPNResponse) { v.WireGuard.Endpoint = "vpn.example.net:51820\nPostUp = sh" }},
+		{"newline in allowed IPs", client.VPNWireGuard, func(v *schema.VPNResponse) { v.WireGuard.AllowedIPs = []string{"0.0.0.0/0\n[Peer]"} }},
+		{"empty endpoint", client.VPNWireGuard, func(v *schema.VPNResponse) { v.WireGuard.Endpoint = "" }},
+		{"empty remote", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.Remote = "" }},
+		{"newline in remote", client.VPNOpenVPN, func(v *schema.VPNResponse) {
+			v.OpenVPN.Remote = "vpn.example.net\nscript-security 2\nup /tmp/x"
+		}},
+		{"space in remote", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.Remote = "vpn.example.net 443" }},
+		{"newline in protocol", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.Protocol = "udp\nup /tmp/x" }},
+		{"closing tag in CA", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.CA = "-----BEGIN CERTIFICATE-----\n</ca>\nup /tmp/x\n<ca>" }},
+		{"closing tag in TLS crypt key", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.TLSCrypt = "key</tls-crypt>" }},
+		{"control character in CA", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.CA = "cert\x00" }},
+		{"newline in username", client.VPNOpenVPN, func(v *schema.VPNResponse) { v.OpenVPN.Username = "user\n</auth-user-pass>" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vpn := testVPN("1", "alice")
+			tt.modify(&vpn)
+			config, err := client.RenderVPNConfig(vpn, tt.format)
+			if !errors.Is(err, client.ErrVPNCredentialsInvalid) {
+				t.Fatalf("RenderVPNConfig() error = %v, want ErrVPNCredentialsInvalid", err)
+			}
+			if config != nil {
+				t.Errorf("RenderVPNConfig() config = %q, want nil", config)
+			}
+			if _, err := client.WriteVPNConfig(vpn, tt.format, t.TempDir()); !errors.Is(err, client.ErrVPNCredentialsInvalid) {
+				t.Errorf("WriteVPNConfig() error = %v, want ErrVPNCredentialsInvalid", err)
+			}
+		})
+	}
+}
+
+func TestRenderVPNConfigKeepsMultiLinePEM(t *testing.T) {
+	vpn := testVPN("1", "alice")
+	vpn.OpenVPN.CA = "-----BEGIN CERTIFICATE-----\r\nMIIB\r\n-----END CERTIFICATE-----"
+	config, err := client.RenderVPNConfig(vpn, client.VPNOpenVPN)
+	if err != nil {
+		t.Fatalf("RenderVPNConfig() error = %v", err)
+	}
+	if !strings.Contains(string(config), "<ca>\n"+vpn.OpenVPN.CA+"\n</ca>") {
+		t.Errorf("config = %q, want the CA inline", config)
+	}
+}
@@ -0,0 +1,254 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ajquack/njalla-dns-go/njalla/schema"
+)
+
+// Payment methods accepted by "add-payment".
+const (
+	PaymentPayPal   string = "paypal"
+	PaymentBitcoin  string = "bitcoin"
+	PaymentLitecoin string = "litecoin"
+	PaymentMonero   string = "monero"
+	PaymentZcash    string = "zcash"
+	PaymentEthereum string = "ethereum"
+)
+
+type WalletClient struct {
+	client *Client
+}
+
+// GetBalance retrieves the balance of the account wallet.
+// It sends a request to the "get-balance" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - The balance in euros.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *WalletClient) GetBalance(ctx context.Context) (int, error) {
+	const method string = "get-balance"
+	var responseScheme schema.GetBalanceRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.GetBalanceParams{}, &responseScheme)
+	if err != nil {
+		return 0, err
+	}
+	response := resp.(*schema.GetBalanceRequestResponse)
+	return response.Balance, nil
+}
+
+// ListTransactions retrieves the payments and charges of the account wallet.
+// It sends a request to the "list-transactions" API endpoint and parses the response.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//
+// Returns:
+//   - A slice of schema.TransactionResponse containing the transactions.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *WalletClient) ListTransactions(ctx context.Context) ([]schema.TransactionResponse, error) {
+	const method string = "list-transactions"
+	var responseScheme schema.ListTransactionsRequestResponse
+
+	resp, err := c.client.call(ctx, method, schema.ListTransactionsParams{}, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.ListTransactionsRequestResponse)
+	return response.Transactions, nil
+}
+
+// AddPayment starts a payment that refills the account wallet.
+// It sends a request to the "add-payment" API endpoint and parses the response.
+// The wallet is credited once the payment is made at the returned address or URL.
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - amount: The amount in euros.
+//   - via: The payment method, e.g. PaymentBitcoin or PaymentPayPal.
+//
+// Returns:
+//   - A pointer to schema.AddPaymentRequestResponse containing the address or
+//     URL to pay to.
+//   - An error if the request fails or the response cannot be parsed.
+func (c *WalletClient) AddPayment(ctx context.Context, amount int, via string) (*schema.AddPaymentRequestResponse, error) {
+	const method string = "add-payment"
+	var responseScheme schema.AddPaymentRequestResponse
+
+	params := schema.AddPaymentParams{
+		Amount: amount,
+		Via:    via,
+	}
+	resp, err := c.client.call(ctx, method, params, &responseScheme)
+	if err != nil {
+		return nil, err
+	}
+	response := resp.(*schema.AddPaymentRequestResponse)
+	return response, nil
+}
+
+// DomainRenewal is an upcoming automatic renewal checked by RenewalShortfall.
+//
+// Fields:
+//   - Name: The domain name.
+//   - Expiry: The expiry date of the domain.
+//   - Price: The renewal price in euros, or 0 if it is unknown.
+//   - Covered: Whether the balance covers this renewal, after the renewals
+//     that are due earlier. Always false if the price is unknown.
+type DomainRenewal struct {
+	Name    string
+	Expiry  time.Time
+	Price   int
+	Covered bool
+}
+
+// RenewalReport compares the upcoming automatic renewals with the balance.
+//
+// Fields:
+//   - Balance: The balance of the account wallet in euros.
+//   - Required: The total price of the upcoming renewals with a known price in euros.
+//   - Shortfall: The amount in euros missing to cover every renewal with a
+//     known price, or 0.
+//   - Renewals: The upcoming renewals, sorted by expiry date.
+//   - Unpriced: The names of the domains whose renewal price is unknown. They
+//     are left out of Required, Shortfall and Failing, so a report with
+//     unpriced renewals is incomplete.
+type RenewalReport struct {
+	Balance   int
+	Required  int
+	Shortfall int
+	Renewals  []DomainRenewal
+	Unpriced  []string
+}
+
+// Failing returns the names of the domains with a known renewal price whose
+// renewal the balance does not cover. It is empty if and only if Shortfall is 0.
+func (r *RenewalReport) Failing() []string {
+	var names []string
+	for _, renewal := range r.Renewals {
+		if renewal.Price > 0 && !renewal.Covered {
+			names = append(names, renewal.Name)
+		}
+	}
+	return names
+}
+
+// RenewalShortfall reports which domains set to renew automatically will fail
+// to renew because the wallet balance is too low. It lists the domains with
+// ListDomains, keeps those with autorenew enabled that expire within the given
+// window, and charges their renewal prices against the balance in order of
+// expiry.
+//
+// Renewal prices are taken from prices, keyed by TLD without the leading dot,
+// e.g. "com". Prices of other TLDs are looked up with FindDomains, which returns
+// the registration price; it may differ from the renewal price, so pass the
+// renewal prices of your TLDs where they are known. A renewal whose price
+// cannot be determined is listed in Unpriced instead of being charged.
+//
+// Parameters:
+//   - ctx: The context for the requests, used for cancellation and deadlines.
+//   - within: How far ahead to look for expiring domains.
+//   - prices: Known renewal prices in euros, keyed by TLD. May be nil.
+//
+// Returns:
+//   - A pointer to a RenewalReport.
+//   - An error if the balance or domains cannot be retrieved, or joining the
+//     errors of failed price lookups, in which case the report is still returned.
+//
+// Example usage:
+//
+//	report, err := c.Wallet.RenewalShortfall(ctx, 30*24*time.Hour, map[string]int{"com": 15})
+//	if err == nil && report.Shortfall > 0 {
+//	    log.Printf("add %d EUR to renew %v", report.Shortfall, report.Failing())
+//	}
+//	if len(report.Unpriced) > 0 {
+//	    log.Printf("unknown renewal prices for %v", report.Unpriced)
+//	}
+func (c *WalletClient) RenewalShortfall(ctx context.Context, within time.Duration, prices map[string]int) (*RenewalReport, error) {
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domains, err := c.client.Domain.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RenewalReport{Balance: balance}
+	deadline := time.Now().Add(within)
+	var errs []error
+	for _, domain := range domains {
+		if !domain.Autorenew {
+			continue
+		}
+		expiry, err := parseExpiry(domain.Expiry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse expiry of %s: %w", domain.Name, err))
+			continue
+		}
+		if expiry.After(deadline) {
+			continue
+		}
+		price, err := c.renewalPrice(ctx, domain.Name, prices)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		report.Renewals = append(report.Renewals, DomainRenewal{Name: domain.Name, Expiry: expiry, Price: price})
+	}
+
+	slices.SortStableFunc(report.Renewals, func(a, b DomainRenewal) int {
+		return a.Expiry.Compare(b.Expiry)
+	})
+	remaining := balance
+	for i, renewal := range report.Renewals {
+		if renewal.Price <= 0 {
+			report.Unpriced = append(report.Unpriced, renewal.Name)
+			continue
+		}
+		report.Required += renewal.Price
+		if renewal.Price <= remaining {
+			remaining -= renewal.Price
+			report.Renewals[i].Covered = true
+		}
+	}
+	report.Shortfall = max(report.Required-balance, 0)
+	return report, errors.Join(errs...)
+}
+
+// renewalPrice returns the renewal price of a domain from prices, or falls back
+// to the registration price returned by FindDomains.
+func (c *WalletClient) renewalPrice(ctx context.Context, name string, prices map[string]int) (int, error) {
+	if _, tld, ok := strings.Cut(name, "."); ok {
+		if price, ok := prices[tld]; ok {
+			return price, nil
+		}
+	}
+	results, err := c.client.Domain.FindDomains(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up price of %s: %w", name, err)
+	}
+	for _, result := range results {
+		if strings.EqualFold(result.Name, name) && result.Price > 0 {
+			return result.Price, nil
+		}
+	}
+	return 0, fmt.Errorf("failed to look up price of %s: no price returned", name)
+}
+
+// parseExpiry parses the expiry date of a domain, which the API returns either
+// as an RFC 3339 timestamp or as a plain date.
+func parseExpiry(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
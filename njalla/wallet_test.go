@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	client "github.com/ajquack/njalla-dns-go/njalla"
+)
+
+func TestRenewalShortfall(t *testing.T) {
+	expiry := func(days int) string {
+		return time.Now().AddDate(0, 0, days).Format(time.DateOnly)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		switch req.Method {
+		case "get-balance":
+			fmt.Fprint(w, `{"result":{"balance":20}}`)
+		case "list-domains":
+			fmt.Fprintf(w, `{"result":{"domains":[
+				{"name":"c.net","expiry":%q,"autorenew":true},
+				{"name":"a.com","expiry":%q,"autorenew":true},
+				{"name":"b.org","expiry":%q,"autorenew":true},
+				{"name":"d.se","expiry":%q,"autorenew":true},
+				{"name":"e.com","expiry":%q,"autorenew":false},
+				{"name":"f.com","expiry":%q,"autorenew":true}
+			]}}`, expiry(15), expiry(5), expiry(10), expiry(12), expiry(1), expiry(90))
+		case "find-domains":
+			fmt.Fprint(w, `{"result":{"domains":[{"name":"d.se","status":"taken","price":4}]}}`)
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+	}))
+	defer srv.Close()
+	c := client.NewClient(client.WithEndpoint(srv.URL))
+
+	report, err := c.Wallet.RenewalShortfall(context.Background(), 30*24*time.Hour, map[string]int{"com": 15, "net": 10})
+	if err == nil {
+		t.Error("RenewalShortfall() error = nil, want the failed price lookup of b.org")
+	}
+	if report == nil {
+		t.Fatal("RenewalShortfall() report = nil, want a report despite the failed lookup")
+	}
+
+	var names []string
+	for _, renewal := range report.Renewals {
+		names = append(names, renewal.Name)
+	}
+	if want := []string{"a.com", "b.org", "d.se", "c.net"}; !slices.Equal(names, want) {
+		t.Errorf("Renewals = %v, want %v", names, want)
+	}
+	if report.Required != 29 || report.Shortfall != 9 {
+		t.Errorf("Required, Shortfall = %d, %d, want 29, 9", report.Required, report.Shortfall)
+	}
+	if got, want := report.Failing(), []string{"c.net"}; !slices.Equal(got, want) {
+		t.Errorf("Failing() = %v, want %v", got, want)
+	}
+	if got, want := report.Unpriced, []string{"b.org"}; !slices.Equal(got, want) {
+		t.Errorf("Unpriced = %v, want %v", got, want)
+	}
+}